@@ -20,7 +20,48 @@ type Config struct {
 		Port string
 	}
 	Log
-	DB map[string]map[string]interface{}
+	DB     map[string]map[string]interface{}
+	Review struct {
+		Blocklist       []string //리뷰 작성시 검토 대상으로 표시할 금칙어
+		ReportThreshold int      //검토 대상으로 표시할 신고 누적 횟수
+	}
+	Storage struct {
+		Path         string //로컬 파일 저장 경로
+		BaseURL      string //이미지 제공 url 경로
+		MaxImageSize int64  //이미지 1장당 최대 크기 (bytes)
+		MaxImages    int    //리뷰당 최대 이미지 개수
+		ThumbWidth   int    //썸네일 가로 크기 (pixel)
+	}
+	Schedule struct {
+		LeadTime int //예약 주문 최소 예약 시간 기본값 (분)
+		PrepTime int //예약 주문 준비 시간 기본값 (분), 예약 시간 전 주방 전달
+		Interval int //예약 주문 전달 확인 주기 (초)
+	}
+	Rider struct {
+		Speed float64 //배달원 평균 이동 속도 (km/h), 도착 예정 시간 계산
+	}
+	Cancel struct {
+		GraceTime int //주문 후 조리중이어도 주문자가 취소 가능한 시간 (분)
+	}
+	Payment struct {
+		Gateway        string //결제 대행사, fake : 개발용 가짜 결제
+		PendingTimeout int    //카드 결제 승인 대기 시간 (분), 지나면 주문 자동 취소
+	}
+	Idempotency struct {
+		TTL   int //Idempotency-Key 보관 시간 (시간)
+		Lease int //처음 요청 처리중 상태 유지 시간 (초), 지나면 같은 요청 재시도 가능
+	}
+	Loyalty struct {
+		Rate         int //수령 완료 주문 결제 금액 대비 적립률 (%)
+		ExpireMonths int //적립금 유효 기간 (개월)
+	}
+	Tax struct {
+		Currency string //가격 통화 ex. KRW
+		VATRate  int    //부가세율 (%), 가격은 부가세 포함
+	}
+	Admin struct {
+		Accounts []string //관리자 계정 (Authorization), /admin 요청 허용
+	}
 }
 
 func GetConfig(fpath string) *Config {
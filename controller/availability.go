@@ -0,0 +1,122 @@
+package controller
+
+// /availability.go : 메뉴 품절, 한정 수량, 주문 가능 시간 관리 및 주문시 수량 차감
+import (
+	"fmt"
+	"lecture/oos/model"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 주문할 메뉴의 주문 가능 여부 확인 후 수량 차감, 실패시 응답 status와 에러 반환
+func (p *Controller) reserveMenu(storeID primitive.ObjectID, menuName string, t, at time.Time) (int, error) {
+	switch err := p.md.ReserveStock(storeID, menuName, t, at); err {
+	case nil:
+		return http.StatusOK, nil
+	case model.ErrMenuNotFound:
+		return http.StatusUnprocessableEntity, err
+	case model.ErrSoldOut, model.ErrNotAvailable:
+		return http.StatusConflict, err
+	default:
+		return http.StatusInternalServerError, err
+	}
+}
+
+// 주문 실패, 변경시 차감했던 수량 복구
+func (p *Controller) releaseMenu(storeID primitive.ObjectID, menuName string, t time.Time) {
+	if err := p.md.ReleaseStock(storeID, menuName, t); err != nil {
+		fmt.Println("Failed to release stock", menuName, err)
+	}
+}
+
+// SoldOut godoc
+// @Summary call SoldOut, return menu status by json.
+// @Description 메뉴 품절 설정 및 해제 기능, 호출할 때마다 전환(피주문자가 수행)
+// @name SoldOut
+// @Accept  json
+// @Produce  json
+// @Param menu path string true "menu"
+// @Router /seller/soldOut/:menu [put]
+// @Success 200 {object} Controller
+func (p *Controller) SoldOut(c *gin.Context) {
+	menuName := c.Param("menu")
+	storeID := sellerStoreID(c)
+
+	burger, err := p.md.GetMenu(storeID, "menu", menuName)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, " Can`t find that menu", nil)
+		return
+	}
+
+	status := model.MenuSoldOut
+	if burger.Status == model.MenuSoldOut {
+		status = model.MenuOrderable
+	}
+	if err := p.md.SetMenuStatus(storeID, menuName, status); err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Fail,parameter not found", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"msg": "Menu status change success", menuName: status})
+	c.Next()
+}
+
+// SetAvailability godoc
+// @Summary call SetAvailability, return "Availability change success" by json.
+// @Description 메뉴 일일 한정 수량 및 주문 가능 시간 설정 기능(피주문자가 수행)
+// @name SetAvailability
+// @Accept  json
+// @Produce  json
+// @Param menu path string true "menu"
+// @Param dailyStock path int false "dailyStock (0 : 제한 없음)"
+// @Param availableFrom path string false "availableFrom (HH:MM)"
+// @Param availableTo path string false "availableTo (HH:MM)"
+// @Router /seller/setAvailability [put]
+// @Success 200 {object} Controller
+func (p *Controller) SetAvailability(c *gin.Context) {
+	menuName := c.PostForm("menu")
+	from := c.PostForm("availableFrom")
+	to := c.PostForm("availableTo")
+
+	if len(menuName) <= 0 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+	dailyStock, err := strconv.Atoi(c.DefaultPostForm("dailyStock", "0"))
+	if err != nil || dailyStock < 0 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "dailyStock must be 0 or more", nil)
+		return
+	}
+	if len(from) > 0 || len(to) > 0 { //시작, 종료 시간은 함께 설정
+		if _, err := time.Parse("15:04", from); err != nil {
+			p.RespError(c, nil, http.StatusUnprocessableEntity, "availableFrom must be HH:MM", nil)
+			return
+		}
+		if _, err := time.Parse("15:04", to); err != nil {
+			p.RespError(c, nil, http.StatusUnprocessableEntity, "availableTo must be HH:MM", nil)
+			return
+		}
+	}
+
+	store, err := p.md.GetStore(sellerStoreID(c))
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get store", err.Error())
+		return
+	}
+
+	//한정 수량 기준일은 주문과 같이 사업장 시간대 날짜
+	if err := p.md.SetAvailability(store.ID, menuName, dailyStock, from, to, time.Now().In(store.Location())); err == model.ErrMenuNotFound {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, " Can`t find that menu", nil)
+		return
+	} else if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Fail,parameter not found", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"msg": "Availability change success"})
+	c.Next()
+}
@@ -0,0 +1,156 @@
+package controller
+
+// /cancel.go : 주문 취소(주문자), 주문 거절(피주문자) 후 수량 복구 및 환불
+import (
+	"fmt"
+	"lecture/oos/model"
+	"lecture/oos/pubsub"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 주문 시간 (사업장 시간대), 수량 차감한 날짜 확인에 사용
+func orderedAt(store model.Store, order model.OrderList) time.Time {
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", order.OrderTime, time.Local)
+	if err != nil {
+		return time.Now().In(store.Location())
+	}
+	return t.In(store.Location())
+}
+
+// 환불 금액, 환불할 결제가 없으면 0
+func refundAmount(refund *model.Refund) int {
+	if refund == nil {
+		return 0
+	}
+	return refund.Amount
+}
+
+// 주문 취소 후 수량 복구, 배달원 복구, 결제 환불 및 구독자에게 알림. 취소 후 상태 반환
+func (p *Controller) cancelOrder(store model.Store, order model.OrderList, cancel model.Cancellation) (string, error) {
+	refund := refundFor(order, cancel.At)
+	state, err := p.md.CancelOrder(order.ID, order.State, cancel, refund)
+	if err != nil {
+		return state, err
+	}
+
+	p.releaseOrder(store, order) //차감한 수량 복구
+	p.releaseLoyalty(order)      //적립금 및 스탬프 복구
+	p.releaseCoupon(order)       //쿠폰 사용 횟수 복구
+	if d := order.Delivery; d != nil {
+		if err := p.md.CancelDelivery(d.RiderID, order.ID); err != nil { //주문은 이미 취소됐으므로 기록만 남김
+			fmt.Println("Failed to release rider", d.RiderID.Hex(), order.ID.Hex(), err)
+		}
+	}
+
+	order.State = state
+	order.Cancellation = &cancel
+	order.Refund = refund
+	if err := p.refundPayment(order); err != nil { //승인 취소 또는 환불
+		fmt.Println("Failed to refund payment", order.ID.Hex(), err)
+	}
+
+	p.publishOrder(order.ID, pubsub.EventCancelled, state, gin.H{"By": cancel.By, "Reason": cancel.Reason, "Note": cancel.Note, "Refund": refundAmount(refund)})
+	p.publishKitchen(order, pubsub.EventCancelled, &pubsub.Alert{Sound: pubsub.SoundCancelled, Repeat: true})
+	return state, nil
+}
+
+// CancelOrder godoc
+// @Summary call CancelOrder, return cancelled state and refund by json.
+// @Description 주문 취소 기능, 결제대기, 접수중 및 예약접수는 항상, 조리중은 주문 후 취소 가능 시간 이내만 가능. 차감된 수량 복구 및 카드 결제 환불(주문자가 수행)
+// @name CancelOrder
+// @Accept  json
+// @Produce  json
+// @Param orderId path string true "orderId"
+// @Param pnum path string true "pnum"
+// @Param note path string false "note (취소 사유)"
+// @Router /customer/cancelOrder [put]
+// @Success 200 {object} Controller
+func (p *Controller) CancelOrder(c *gin.Context) {
+	pnum := c.PostForm("pnum")
+	orderID, err := primitive.ObjectIDFromHex(c.PostForm("orderId"))
+	if len(pnum) <= 0 || err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	order, err := p.md.GetOrder(orderID)
+	if err != nil || order.Pnum != pnum {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find your order", nil)
+		return
+	}
+	store, err := p.md.GetStore(order.StoreID)
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get store", err.Error())
+		return
+	}
+
+	now := time.Now()
+	switch {
+	case order.State == model.StateReceived || order.State == model.StateScheduled || order.State == model.StatePendingPayment:
+	case order.State == model.StateCooking && now.Before(orderedAt(store, order).Add(time.Duration(p.cf.Cancel.GraceTime)*time.Minute)):
+	default:
+		p.RespError(c, nil, http.StatusConflict, "Sorry, You can not cancel this order", order.State)
+		return
+	}
+
+	cancel := model.Cancellation{By: model.CancelByCustomer, Reason: model.CancelByCustomer, Note: c.PostForm("note"), At: now}
+	state, err := p.cancelOrder(store, order, cancel)
+	if err != nil {
+		p.RespError(c, nil, http.StatusConflict, "Can`t cancel order", err.Error())
+		return
+	}
+
+	c.JSON(200, gin.H{"msg": "Order cancelled", "State": state, "Refund": refundAmount(refundFor(order, now))})
+	c.Next()
+}
+
+// RejectOrder godoc
+// @Summary call RejectOrder, return rejected state and refund by json.
+// @Description 주문 거절 기능, 조리 완료 전 주문만 가능. 사유 코드 soldOut, tooBusy, closing, outOfZone, other(note 필수). 차감된 수량 복구 및 카드 결제 환불(피주문자가 수행)
+// @name RejectOrder
+// @Accept  json
+// @Produce  json
+// @Param orderId path string true "orderId"
+// @Param reason path string true "reason"
+// @Param note path string false "note"
+// @Router /seller/rejectOrder [put]
+// @Success 200 {object} Controller
+func (p *Controller) RejectOrder(c *gin.Context) {
+	reason := c.PostForm("reason")
+	note := c.PostForm("note")
+	if !model.ValidRejectReason(reason) {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "reason must be soldOut, tooBusy, closing, outOfZone or other", nil)
+		return
+	}
+	if reason == model.RejectOther && len(note) <= 0 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "note not found", nil)
+		return
+	}
+	order, ok := p.kitchenOrder(c)
+	if !ok {
+		return
+	}
+	if order.Cancelled() || order.Cooked() {
+		p.RespError(c, nil, http.StatusConflict, "Can`t reject order in state "+order.State, nil)
+		return
+	}
+	store, err := p.md.GetStore(order.StoreID)
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get store", err.Error())
+		return
+	}
+
+	cancel := model.Cancellation{By: model.CancelBySeller, Reason: reason, Note: note, At: time.Now()}
+	state, err := p.cancelOrder(store, order, cancel)
+	if err != nil {
+		p.RespError(c, nil, http.StatusConflict, "Can`t reject order", err.Error())
+		return
+	}
+
+	c.JSON(200, gin.H{"msg": "Order rejected", "State": state, "Reason": reason, "Refund": refundAmount(refundFor(order, time.Now()))})
+	c.Next()
+}
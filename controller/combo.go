@@ -0,0 +1,83 @@
+package controller
+
+// /combo.go : 세트 메뉴 구성 설정 및 주문시 세트 구성 입력 처리
+import (
+	"encoding/json"
+	"fmt"
+	"lecture/oos/model"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 세트 구성은 "슬롯:메뉴" 형식, 여러개 입력 가능 ex. combo=사이드:감자튀김&combo=음료:콜라
+func parseComboChoices(values []string) ([]model.ComboChoice, error) {
+	choices := []model.ComboChoice{}
+	for _, v := range splitValues(values) {
+		parts := strings.SplitN(v, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("combo must be slot:menu, %s", v)
+		}
+		choices = append(choices, model.ComboChoice{Slot: strings.TrimSpace(parts[0]), Menu: strings.TrimSpace(parts[1])})
+	}
+	return choices, nil
+}
+
+// SetComboSlots godoc
+// @Summary call SetComboSlots, return "Combo change success" by json.
+// @Description 세트 메뉴 구성 설정 기능, slots는 json 배열 ex. [{"Name":"사이드","Choices":[{"Menu":"감자튀김","Upcharge":0},{"Menu":"치즈스틱","Upcharge":500}]}](피주문자가 수행)
+// @name SetComboSlots
+// @Accept  json
+// @Produce  json
+// @Param menu path string true "menu"
+// @Param slots path string true "slots"
+// @Router /seller/setComboSlots [put]
+// @Success 200 {object} Controller
+func (p *Controller) SetComboSlots(c *gin.Context) {
+	menuName := c.PostForm("menu")
+	sSlots := c.PostForm("slots")
+
+	if len(menuName) <= 0 || len(sSlots) <= 0 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	slots := []model.ComboSlot{}
+	if err := json.Unmarshal([]byte(sSlots), &slots); err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "slots must be json array", err.Error())
+		return
+	}
+	if err := model.ValidateComboSlots(slots); err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+		return
+	}
+
+	storeID := sellerStoreID(c)
+	combo, err := p.md.GetMenu(storeID, "menu", menuName)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, " Can`t find that menu", nil)
+		return
+	}
+	//구성 메뉴는 같은 사업장에 등록된 단품 메뉴만 가능
+	for _, slot := range slots {
+		for _, ch := range slot.Choices {
+			burger, err := p.md.GetMenu(storeID, "menu", ch.Menu)
+			if err != nil {
+				p.RespError(c, nil, http.StatusUnprocessableEntity, " Can`t find that menu, "+ch.Menu, nil)
+				return
+			} else if burger.IsCombo() || burger.ID == combo.ID {
+				p.RespError(c, nil, http.StatusUnprocessableEntity, "Combo can not contain combo menu, "+ch.Menu, nil)
+				return
+			}
+		}
+	}
+
+	if err := p.md.SetComboSlots(combo.ID, slots); err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Fail,parameter not found", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"msg": "Combo change success", "Slots": slots})
+	c.Next()
+}
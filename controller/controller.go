@@ -4,20 +4,35 @@ package controller
 import (
 	"encoding/json"
 	"fmt"
+	"lecture/oos/conf"
 	"lecture/oos/model"
+	"lecture/oos/payment"
+	"lecture/oos/pubsub"
+	"lecture/oos/storage"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type Controller struct {
 	md *model.Model
+	cf *conf.Config
+	bs storage.BlobStore
+	ps pubsub.Broker
+	pg payment.Gateway
 }
 
-func NewCTL(rep *model.Model) (*Controller, error) {
-	r := &Controller{md: rep}
+func NewCTL(rep *model.Model, cf *conf.Config, bs storage.BlobStore, ps pubsub.Broker, pg payment.Gateway) (*Controller, error) {
+	if cur := cf.Tax.Currency; len(cur) > 0 && !model.ValidCurrency(cur) {
+		return nil, fmt.Errorf("unsupported currency %q", cur)
+	}
+	if rate := cf.Tax.VATRate; rate < 0 || rate > 100 {
+		return nil, fmt.Errorf("vatRate must be between 0 and 100, got %d", rate)
+	}
+	r := &Controller{md: rep, cf: cf, bs: bs, ps: ps, pg: pg}
 	return r, nil
 }
 
@@ -49,12 +64,18 @@ func (p *Controller) RespError(c *gin.Context, body interface{}, status int, err
 // @Accept  json
 // @Produce  json
 // @Param sortOption path string true "sortOption"
+// @Param storeId query string true "storeId"
 // @Router /customer/getMenu/:sortOption [get]
 // @Success 200 {object} Controller
 func (p *Controller) GetMenu(c *gin.Context) {
 	r, _ := model.NewModel()
 	sortOption := c.Param("sortOption")
-	c.JSON(200, gin.H{"Sort Option": sortOption, "Menu List": r.GetAllMenu(sortOption)})
+	storeID, err := p.customerStoreID(c)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+		return
+	}
+	c.JSON(200, gin.H{"Sort Option": sortOption, "Menu List": r.GetAllMenu(storeID, sortOption)})
 	c.Next()
 }
 
@@ -65,52 +86,161 @@ func (p *Controller) GetMenu(c *gin.Context) {
 // @Accept  json
 // @Produce  json
 // @Param menuName path string true "menuName"
+// @Param storeId query string true "storeId"
 // @Router /customer/getReview/:menuName [get]
 // @Success 200 {object} Controller
 func (p *Controller) GetReview(c *gin.Context) {
 	r, _ := model.NewModel()
 	menuName := c.Param("menuName")
-	review := r.GetReview(menuName)
-	if review == (model.MenuReview{}) { //해당 메뉴의 리뷰 내역이 없으면
+	storeID, err := p.customerStoreID(c)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+		return
+	}
+	review, err := r.GetReview(storeID, menuName)
+	if err != nil { //해당 메뉴의 리뷰 내역이 없으면
 		p.RespError(c, nil, http.StatusUnprocessableEntity, "You didn`t wirte review before", nil)
 		return
 	}
 
-	c.JSON(200, review)
+	reviews := []model.MenuReview{review}
+	p.setImageURLs(reviews)
+	c.JSON(200, reviews[0])
+	c.Next()
+}
+
+// GetReviewList godoc
+// @Summary call GetReviewList, return MenuReview list and review stats by json.
+// @Description 메뉴별 전체 리뷰를 최신순으로 페이지 단위 조회, 평균/개수/평점별 분포 포함(주문자가 수행)
+// @name GetReviewList
+// @Accept  json
+// @Produce  json
+// @Param menuName path string true "menuName"
+// @Param page query int false "page (default 1)"
+// @Param size query int false "size (default 10, max 50)"
+// @Param storeId query string true "storeId"
+// @Router /customer/getReviewList/:menuName [get]
+// @Success 200 {object} Controller
+func (p *Controller) GetReviewList(c *gin.Context) {
+	menuName := c.Param("menuName")
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "page must be a positive number", nil)
+		return
+	}
+	size, err := strconv.Atoi(c.DefaultQuery("size", "10"))
+	if err != nil || size < 1 || size > 50 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "size must be between 1 and 50", nil)
+		return
+	}
+	storeID, err := p.customerStoreID(c)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+		return
+	}
+
+	reviews, err := p.md.GetReviewList(storeID, menuName, page, size)
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get review list", err.Error())
+		return
+	}
+	p.setImageURLs(reviews)
+	stats, err := p.md.GetReviewStats(storeID, menuName)
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get review stats", err.Error())
+		return
+	}
+
+	c.JSON(200, gin.H{
+		"Menu":        menuName,
+		"Page":        page,
+		"Size":        size,
+		"Stats":       stats,
+		"Review List": reviews,
+	})
 	c.Next()
 }
 
 // WriteReview godoc
 // @Summary call WriteReview, return "Your review registered" by json.
-// @Description 메뉴별 평점 작성기능(주문자가 수행)
+// @Description 배달/픽업/서빙 완료된 본인 주문의 메뉴별 평점 작성기능, 주문 메뉴당 1회(주문자가 수행)
 // @name WriteReview
 // @Accept  json
 // @Produce  json
+// @Param orderId path string true "orderId"
+// @Param pnum path string true "pnum"
 // @Param menu path string true "menu"
 // @Param grade path string true "grade"
 // @Param review path string true "review"
+// @Param images formData file false "images (jpeg, png, gif)"
 // @Router /customer/writeReview [post]
 // @Success 200 {object} Controller
 func (p *Controller) WriteReview(c *gin.Context) {
+	sOrderID := c.PostForm("orderId")
+	pnum := c.PostForm("pnum")
 	menuName := c.PostForm("menu")
 	sGrade := c.PostForm("grade")
 	review := c.PostForm("review")
 
-	if len(menuName) <= 0 || len(review) <= 0 {
+	if len(sOrderID) <= 0 || len(pnum) <= 0 || len(menuName) <= 0 || len(review) <= 0 {
 		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
 		return
 	}
 
-	orderList, _ := p.md.GetOrderListByMenu("menu", menuName)
-	if orderList == (model.OrderList{}) { //해당 메뉴의 주문 내역이 없으면
+	orderID, err := primitive.ObjectIDFromHex(sOrderID)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "invalid orderId", nil)
+		return
+	}
+	orderList, err := p.md.GetOrder(orderID)
+	if err != nil || orderList.Pnum != pnum { //본인의 주문 내역이 아니면
 		p.RespError(c, nil, http.StatusUnprocessableEntity, "You didn`t ordered that menu before", nil)
 		return
 	}
+	if !containsMenu(orderList.MenuNames(), menuName) {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "That menu is not in your order", nil)
+		return
+	}
+	if !orderList.Completed() { //배달완료, 픽업완료, 서빙완료 후에만 작성 가능
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "You can write review after your order is completed", nil)
+		return
+	}
+	if exists, err := p.md.HasReview(orderID, menuName); err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to check review", err.Error())
+		return
+	} else if exists {
+		p.RespError(c, nil, http.StatusConflict, "You already wrote review for that order", nil)
+		return
+	}
 
-	grade, _ := strconv.Atoi(sGrade)
-	req := model.MenuReview{Menu: menuName, Grade: grade, Review: review} //리뷰 db에 저장
+	grade, err := strconv.Atoi(sGrade)
+	if err != nil || grade < 1 || grade > 5 { //평점은 1~5점
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "grade must be between 1 and 5", nil)
+		return
+	}
+	images, err := p.readImages(c) //첨부 이미지 크기, 타입 검증 및 썸네일 생성
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+		return
+	}
+	createdAt := time.Now().Format("2006-01-02 15:04:05")
+	req := model.MenuReview{ID: primitive.NewObjectID(), StoreID: orderList.StoreID, OrderID: orderID, Pnum: pnum, Menu: menuName, Grade: grade, Review: review, CreatedAt: createdAt} //리뷰 db에 저장
+	if req.Images, err = p.saveImages(req.ID.Hex(), images); err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to save images", err.Error())
+		return
+	}
+	if reason := p.filterReview(review); len(reason) > 0 { //금칙어 포함시 관리자 검토 대상
+		req.Flagged, req.FlagReason = true, reason
+	}
 	if err := p.md.WriteReview(req); err != nil {
-		p.RespError(c, nil, http.StatusUnprocessableEntity, "You didn`t order that menu", nil)
+		if exists, _ := p.md.HasReview(orderID, menuName); !exists || err == model.ErrReviewExists { //리뷰가 저장되지 않았으면 이미지 삭제
+			p.deleteImages(req.Images)
+		}
+		if err == model.ErrReviewExists {
+			p.RespError(c, nil, http.StatusConflict, "You already wrote review for that order", nil)
+		} else {
+			p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to write review", nil)
+		}
 		return
 	}
 
@@ -119,98 +249,364 @@ func (p *Controller) WriteReview(c *gin.Context) {
 
 }
 
+// UpdateReview godoc
+// @Summary call UpdateReview, return "Your review updated" by json.
+// @Description 본인이 작성한 리뷰의 평점 및 내용 수정기능(주문자가 수행)
+// @name UpdateReview
+// @Accept  json
+// @Produce  json
+// @Param reviewId path string true "reviewId"
+// @Param pnum path string true "pnum"
+// @Param grade path string true "grade"
+// @Param review path string true "review"
+// @Router /customer/updateReview [put]
+// @Success 200 {object} Controller
+func (p *Controller) UpdateReview(c *gin.Context) {
+	sReviewID := c.PostForm("reviewId")
+	pnum := c.PostForm("pnum")
+	sGrade := c.PostForm("grade")
+	review := c.PostForm("review")
+
+	if len(sReviewID) <= 0 || len(pnum) <= 0 || len(review) <= 0 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	reviewID, err := primitive.ObjectIDFromHex(sReviewID)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "invalid reviewId", nil)
+		return
+	}
+	grade, err := strconv.Atoi(sGrade)
+	if err != nil || grade < 1 || grade > 5 { //평점은 1~5점
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "grade must be between 1 and 5", nil)
+		return
+	}
+
+	updatedAt := time.Now().Format("2006-01-02 15:04:05")
+	if err := p.md.UpdateReview(reviewID, pnum, grade, review, updatedAt, p.filterReview(review)); err == model.ErrReviewNotFound {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find your review", nil)
+		return
+	} else if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to update review", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Your review updated"})
+	c.Next()
+}
+
+// DeleteReview godoc
+// @Summary call DeleteReview, return "Your review deleted" by json.
+// @Description 본인이 작성한 리뷰 삭제기능(주문자가 수행)
+// @name DeleteReview
+// @Accept  json
+// @Produce  json
+// @Param reviewId path string true "reviewId"
+// @Param pnum query string true "pnum"
+// @Router /customer/deleteReview/:reviewId [delete]
+// @Success 200 {object} Controller
+func (p *Controller) DeleteReview(c *gin.Context) {
+	sReviewID := c.Param("reviewId")
+	pnum := c.Query("pnum")
+
+	if len(sReviewID) <= 0 || len(pnum) <= 0 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	reviewID, err := primitive.ObjectIDFromHex(sReviewID)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "invalid reviewId", nil)
+		return
+	}
+
+	deleted, err := p.md.DeleteReview(reviewID, pnum)
+	if err == model.ErrReviewNotFound {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find your review", nil)
+		return
+	} else if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to delete review", nil)
+		return
+	}
+	p.deleteImages(deleted.Images)
+
+	c.JSON(200, gin.H{"result": "Your review deleted"})
+	c.Next()
+}
+
 // OrderMenu godoc
 // @Summary call OrderMenu, return "Order Success", count by json.
-// @Description 메뉴 주문기능과 주문번호 받는 기능(주문자가 수행)
+// @Description 메뉴 주문기능과 주문번호 받는 기능, 옵션은 그룹:옵션 형식, 영업 시간 외에는 예약 주문만 가능, 주소는 배달 주문만 필요, 카드 결제는 confirmPayment로 승인 후 접수(주문자가 수행)
 // @name OrderMenu
 // @Accept  json
 // @Produce  json
 // @Param menu path string true "menu"
+// @Param options path string false "options (그룹:옵션)"
+// @Param combo path string false "combo (슬롯:메뉴, 세트 메뉴만)"
+// @Param storeId path string true "storeId"
 // @Param pnum path string true "pnum"
-// @Param address path string true "address"
+// @Param fulfilment path string false "fulfilment (delivery, pickup, dineIn / default delivery)"
+// @Param addressId path string false "addressId (저장된 배달 주소)"
+// @Param address path string false "address (addressId가 없을 때 직접 입력, 둘다 없으면 기본 주소)"
+// @Param lat path number false "lat (직접 입력 주소 좌표, 배달 구역 확인)"
+// @Param lng path number false "lng (직접 입력 주소 좌표, 배달 구역 확인)"
+// @Param scheduledTime path string false "scheduledTime (YYYY-MM-DD HH:MM, 사업장 시간대, 최소 예약 시간 이후)"
+// @Param paymentMethod path string false "paymentMethod (card/cash, 기본 card)"
+// @Param coupon path string false "coupon (쿠폰 코드, pnum 필요)"
+// @Param usePoints path int false "usePoints (사용할 적립금, pnum 필요)"
+// @Param stampCard path string false "stampCard (보상 메뉴를 받을 스탬프 카드 id, pnum 필요)"
+// @Param Idempotency-Key header string false "Idempotency-Key (재시도시 같은 계정, pnum에서 같은 키와 같은 내용이면 처음 응답 반환)"
 // @Router /customer/orderMenu [post]
 // @Success 200 {object} Controller
 func (p *Controller) OrderMenu(c *gin.Context) {
 	menuName := c.PostForm("menu")
 	pnum := c.PostForm("pnum")
 	address := c.PostForm("address")
+	fulfilment := c.DefaultPostForm("fulfilment", model.FulfilmentDelivery)
+	method := c.DefaultPostForm("paymentMethod", model.PayCard)
 	orderTime := time.Now().Format("2006-01-02 15:04:05")
-	state := "접수중" //최초 상태는 접수중...
+	state := model.StateReceived //최초 상태는 접수중...
 
-	if len(menuName) <= 0 || len(address) <= 0 {
+	if !model.ValidFulfilment(fulfilment) {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "fulfilment must be delivery, pickup or dineIn", nil)
+		return
+	}
+	if !model.ValidPaymentMethod(method) {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "paymentMethod must be card or cash", nil)
+		return
+	}
+	if len(menuName) <= 0 {
 		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
 		return
 	}
+	if len(c.PostForm("coupon")) > 0 && len(pnum) <= 0 { //고객당 사용 횟수는 고객 번호로 확인
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "pnum is required to use a coupon", nil)
+		return
+	}
+	if usePoints, _ := formInt(c, "usePoints", 0, -1); len(pnum) <= 0 && ((usePoints != nil && *usePoints > 0) || len(c.PostForm("stampCard")) > 0) { //적립금, 스탬프는 고객 번호의 원장에서 차감
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "pnum is required to use points or a stamp card", nil)
+		return
+	}
+	var deliveryAddress *model.Address
+	if fulfilment == model.FulfilmentDelivery { //주소는 배달 주문만 필요
+		lat, err := formFloat(c, "lat")
+		if err != nil {
+			p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+			return
+		}
+		lng, err := formFloat(c, "lng")
+		if err != nil {
+			p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+			return
+		}
+		if deliveryAddress, address, err = p.deliveryAddress(pnum, c.PostForm("addressId"), address, lat, lng); err != nil {
+			p.RespError(c, nil, http.StatusUnprocessableEntity, "Can`t find delivery address", err.Error())
+			return
+		}
+	} else {
+		address = ""
+	}
+	store, err := p.customerStore(c)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+		return
+	}
+	storeID := store.ID
 
-	req := model.OrderList{Menu: menuName, Pnum: pnum, Address: address, OrderTime: orderTime, State: state}
+	now := time.Now().In(store.Location())
+	sScheduled := c.PostForm("scheduledTime")
+	at, status, err := p.acceptOrder(store, now, sScheduled) //영업 시간, 휴무일, 일시 중지 확인
+	if err != nil {
+		p.RespError(c, nil, status, "Store is not taking orders", err.Error())
+		return
+	}
+	scheduledTime := ""
+	var releaseAt time.Time
+	if len(sScheduled) > 0 { //예약 주문은 주방 전달 시간까지 대기
+		state = model.StateScheduled
+		scheduledTime = at.Format("2006-01-02 15:04:05")
+		releaseAt = store.ReleaseTime(at, p.cf.Schedule.PrepTime)
+	}
 
-	if err := p.md.OrderMenu(req); err != nil {
-		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+	item, status, err := p.orderItem(storeID, menuName, c.PostFormArray("options"), c.PostFormArray("combo"), now, at) //옵션 검증, 가격 계산 및 수량 차감
+	if err != nil {
+		p.RespError(c, nil, status, "Can`t order that menu", err.Error())
 		return
 	}
 
-	r, _ := model.NewModel()
-	count := 0
-	orders := r.GetAllOrderList()
-	for _, order := range orders {
-		if order == (model.OrderList{}) { //주문 내역 없으면 null
-			p.RespError(c, nil, http.StatusUnprocessableEntity, "Null", nil)
+	req := model.OrderList{StoreID: storeID, Menu: menuName, Pnum: pnum, Fulfilment: fulfilment, Address: address, DeliveryAddress: deliveryAddress, OrderTime: orderTime, ScheduledTime: scheduledTime, ReleaseAt: releaseAt, State: state, Items: []model.OrderItem{item}}
+	if status, err := priceOrder(store, &req); err != nil { //배달 구역, 최소 주문 금액 확인 및 배달비 추가
+		p.releaseItems(storeID, req.Items, now)
+		p.RespError(c, nil, status, "We can`t deliver that order", err.Error())
+		return
+	}
+	req.Currency, req.VATRate = p.taxDefaults()
+	if coupon := c.PostForm("coupon"); len(coupon) > 0 { //쿠폰 할인 및 증정 메뉴
+		if status, err := p.applyCoupon(store, &req, coupon, now, at); err != nil {
+			p.releaseItems(storeID, req.Items, now)
+			p.RespError(c, nil, status, "Can`t use that coupon", err.Error())
 			return
 		}
-		count++
+	}
+	points, err := formInt(c, "usePoints", 0, -1)
+	if err != nil {
+		p.releaseItems(storeID, req.Items, now)
+		p.releaseCoupon(req)
+		p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+		return
+	}
+	if stampCard := c.PostForm("stampCard"); (points != nil && *points > 0) || len(stampCard) > 0 { //적립금 사용 및 스탬프 보상 메뉴
+		usePoints := 0
+		if points != nil {
+			usePoints = *points
+		}
+		if status, err := p.applyLoyalty(store, &req, usePoints, stampCard, now, at); err != nil {
+			p.releaseItems(storeID, req.Items, now)
+			p.releaseCoupon(req)
+			p.RespError(c, nil, status, "Can`t use points or stamps", err.Error())
+			return
+		}
+	}
+	intent, err := p.startPayment(&req, method) //카드 결제는 승인 후 접수
+	if err != nil {
+		p.releaseItems(storeID, req.Items, now)
+		p.releaseCoupon(req)
+		p.releaseLoyalty(req)
+		p.RespError(c, nil, http.StatusBadGateway, "Payment is not available", err.Error())
+		return
 	}
 
+	orderID, err := p.md.OrderMenu(req)
+	if err != nil {
+		p.releaseItems(storeID, req.Items, now)
+		p.releaseCoupon(req)
+		p.releaseLoyalty(req)
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	req.ID = orderID
+	p.publishKitchen(req, pubsub.EventNew, &pubsub.Alert{Sound: pubsub.SoundNewOrder, Repeat: true})
+
+	count := len(p.md.GetAllOrderList(storeID, "")) //사업장별 주문번호
+
 	c.JSON(200, gin.H{
-		"result":       "Order Success",
-		"Order Number": count, //주문번호
+		"result":        "Order Success",
+		"Order Number":  count,         //주문번호
+		"Order ID":      orderID.Hex(), //리뷰 작성, 상태 변경시 사용
+		"Fulfilment":    req.Fulfilment,
+		"State":         req.State,
+		"Scheduled":     req.ScheduledTime,
+		"Items":         req.Items,
+		"Subtotal":      req.Subtotal,
+		"Delivery Fee":  req.DeliveryFee,
+		"Coupon":        req.Coupon,
+		"Discount":      req.Discount,
+		"Points Used":   req.PointsUsed,
+		"Tax":           req.Tax(p.taxDefaults()),
+		"Price":         req.Price,
+		"Payment":       req.Payment.Method,
+		"Intent ID":     intent.ID, //카드 결제만, 결제 화면에서 승인 후 confirmPayment
+		"Client Secret": intent.ClientSecret,
 	})
 	c.Next()
 }
 
 // AddMenu godoc
 // @Summary call AddMenu, return success,fail by json.
-// @Description 메뉴추가 기능과 배달중이면 신규주문 접수 기능(주문자가 수행)
+// @Description 메뉴추가 기능과 배달중/픽업대기 등 조리가 끝났으면 신규주문 접수 기능, 신규주문은 현금 결제(주문자가 수행)
 // @name AddMenu
 // @Accept  json
 // @Produce  json
 // @Param menu path string true "menu"
 // @Param changeMenu path string true "changeMenu"
+// @Param options path string false "options (그룹:옵션)"
+// @Param combo path string false "combo (슬롯:메뉴, 세트 메뉴만)"
+// @Param storeId path string true "storeId"
 // @Router /customer/addMenu [put]
 // @Success 200 {object} Controller
 func (p *Controller) AddMenu(c *gin.Context) {
 	beforeMenu := c.PostForm("menu")
 	addMenu := c.PostForm("changeMenu")
+	store, err := p.customerStore(c)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+		return
+	}
+	storeID := store.ID
 
-	orderList, _ := p.md.GetOrderListByMenu("menu", beforeMenu)
-	if orderList == (model.OrderList{}) { //해당 메뉴의 주문 내역이 없으면
+	orderList, err := p.md.GetOrderListByMenu(storeID, "menu", beforeMenu)
+	if err != nil { //해당 메뉴의 주문 내역이 없으면
 		p.RespError(c, nil, http.StatusUnprocessableEntity, " You didn`t ordered that menu before", nil)
 		return
 	}
+	if orderList.PaidByCard() { //승인 금액이 정해진 카드 결제 주문은 변경 불가
+		p.RespError(c, nil, http.StatusConflict, "Card paid orders can not be changed, please place a new order", nil)
+		return
+	}
+	if orderList.Discounted() { //할인 금액이 달라지므로 쿠폰, 적립금, 스탬프 사용 주문은 변경 불가
+		p.RespError(c, nil, http.StatusConflict, "Orders with a coupon, points or stamps can not be changed, please place a new order", nil)
+		return
+	}
 
-	if orderList.State == "배달중" { // 신규 주문으로 전환
+	now := time.Now().In(store.Location())
+	at := orderAt(store, orderList, now)
+	if orderList.Cooked() { //신규 주문은 영업 시간 확인
+		if _, status, err := p.acceptOrder(store, now, ""); err != nil {
+			p.RespError(c, nil, status, "Store is not taking orders", err.Error())
+			return
+		}
+		at = now
+	}
+	item, status, err := p.orderItem(storeID, addMenu, c.PostFormArray("options"), c.PostFormArray("combo"), now, at) //추가 메뉴 옵션 검증, 가격 계산 및 수량 차감
+	if err != nil {
+		p.RespError(c, nil, status, "Can`t order that menu", err.Error())
+		return
+	}
+
+	if orderList.Cooked() { // 신규 주문으로 전환
 
 		pnum := orderList.Pnum
 		address := orderList.Address
 		orderTime := time.Now().Format("2006-01-02 15:04:05")
-		state := "접수중"
-		req := model.OrderList{Menu: addMenu, Pnum: pnum, Address: address, OrderTime: orderTime, State: state}
+		state := model.StateReceived
+		req := model.OrderList{StoreID: storeID, Menu: addMenu, Pnum: pnum, Fulfilment: orderList.FulfilmentType(), Address: address, DeliveryAddress: orderList.DeliveryAddress, OrderTime: orderTime, State: state, Items: []model.OrderItem{item}}
+		if status, err := priceOrder(store, &req); err != nil {
+			p.releaseItems(storeID, req.Items, now)
+			p.RespError(c, nil, status, "We can`t deliver that order", err.Error())
+			return
+		}
+		req.Currency, req.VATRate = p.taxDefaults()
+		if _, err := p.startPayment(&req, model.PayCash); err != nil { //카드 결제 주문은 위에서 거절, 기존 주문과 같이 만나서 결제
+			p.releaseItems(storeID, req.Items, now)
+			p.RespError(c, nil, http.StatusBadGateway, "Payment is not available", err.Error())
+			return
+		}
 
-		if err := p.md.OrderMenu(req); err != nil {
+		orderID, err := p.md.OrderMenu(req)
+		if err != nil {
+			p.releaseItems(storeID, req.Items, now)
 			p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
 			return
 		}
+		req.ID = orderID
+		p.publishKitchen(req, pubsub.EventNew, &pubsub.Alert{Sound: pubsub.SoundNewOrder, Repeat: true})
 		c.JSON(200, gin.H{
 			"msg":       "Sorry, You can not add menu.I will make you new order",
 			"New order": req,
+			"Order ID":  orderID.Hex(),
 		})
 		c.Next()
 	} else {
-		addMenu = beforeMenu + " , " + addMenu
-		if err := p.md.ChangeMenu(beforeMenu, addMenu); err != nil {
+		if err := p.md.AddOrderItem(orderList.ID, beforeMenu+model.MenuSeparator+addMenu, item); err != nil {
+			p.releaseItems(storeID, []model.OrderItem{item}, now)
 			p.RespError(c, nil, http.StatusUnprocessableEntity, "Fail,parameter not found", nil)
 			return
 		}
-		c.JSON(200, gin.H{"msg": "Menu add success"})
+		p.publishOrder(orderList.ID, pubsub.EventUpdated, orderList.State, gin.H{"Added": item, "Price": orderList.Price + item.Price})
+		p.publishKitchenUpdate(orderList.ID)
+		c.JSON(200, gin.H{"msg": "Menu add success", "Item": item, "Price": orderList.Price + item.Price})
 		c.Next()
 	}
 
@@ -218,12 +614,15 @@ func (p *Controller) AddMenu(c *gin.Context) {
 
 // ChangeMenu godoc
 // @Summary call ChangeMenu, return success,fail by json.
-// @Description 메뉴변경 기능과 조리중/배달중이면 변경 미수행 기능(주문자가 수행)
+// @Description 메뉴변경 기능과 조리중/배달중/픽업대기면 변경 미수행 기능, 예약 주문은 변경 가능, 배달 구역 최소 주문 금액 미만으로 변경 불가(주문자가 수행)
 // @name ChangeMenu
 // @Accept  json
 // @Produce  json
 // @Param menu path string true "menu"
 // @Param changeMenu path string true "changeMenu"
+// @Param options path string false "options (그룹:옵션)"
+// @Param combo path string false "combo (슬롯:메뉴, 세트 메뉴만)"
+// @Param storeId path string true "storeId"
 // @Router /customer/changeMenu [put]
 // @Success 200 {object} Controller
 func (p *Controller) ChangeMenu(c *gin.Context) {
@@ -234,22 +633,51 @@ func (p *Controller) ChangeMenu(c *gin.Context) {
 		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
 		return
 	}
+	store, err := p.customerStore(c)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+		return
+	}
+	storeID := store.ID
 
-	orderList, _ := p.md.GetOrderListByMenu("menu", beforeMenu)
-	if orderList == (model.OrderList{}) { //해당 메뉴의 주문 내역이 없으면
+	orderList, err := p.md.GetOrderListByMenu(storeID, "menu", beforeMenu)
+	if err != nil { //해당 메뉴의 주문 내역이 없으면
 		p.RespError(c, nil, http.StatusUnprocessableEntity, " You didn`t ordered that menu before", nil)
 		return
 	}
+	if orderList.PaidByCard() { //승인 금액이 정해진 카드 결제 주문은 변경 불가
+		p.RespError(c, nil, http.StatusConflict, "Card paid orders can not be changed, please place a new order", nil)
+		return
+	}
+	if orderList.Discounted() { //할인 금액이 달라지므로 쿠폰, 적립금, 스탬프 사용 주문은 변경 불가
+		p.RespError(c, nil, http.StatusConflict, "Orders with a coupon, points or stamps can not be changed, please place a new order", nil)
+		return
+	}
 
-	if orderList.State == "조리중" || orderList.State == "배달중" {
+	if orderList.State == model.StateCooking || orderList.Cooked() {
 		c.JSON(200, gin.H{"msg": "Sorry, You can not change menu."})
 		c.Next()
-	} else if orderList.State == "접수중" {
-		if err := p.md.ChangeMenu(beforeMenu, afterMenu); err != nil {
+	} else if orderList.State == model.StateReceived || orderList.State == model.StateScheduled {
+		now := time.Now().In(store.Location())
+		item, status, err := p.orderItem(storeID, afterMenu, c.PostFormArray("options"), c.PostFormArray("combo"), now, orderAt(store, orderList, now)) //변경할 메뉴 옵션 검증, 가격 계산 및 수량 차감
+		if err != nil {
+			p.RespError(c, nil, status, "Can`t order that menu", err.Error())
+			return
+		}
+		if err := checkMinOrder(store, orderList, item.Price); err != nil { //배달 구역 최소 주문 금액 확인
+			p.releaseItems(storeID, []model.OrderItem{item}, now)
+			p.RespError(c, nil, http.StatusUnprocessableEntity, "We can`t deliver that order", err.Error())
+			return
+		}
+		if err := p.md.ChangeMenu(orderList.ID, afterMenu, []model.OrderItem{item}, item.Price, item.Price+orderList.DeliveryFee); err != nil {
+			p.releaseItems(storeID, []model.OrderItem{item}, now)
 			p.RespError(c, nil, http.StatusUnprocessableEntity, "Fail,parameter not found", nil)
 			return
 		}
-		c.JSON(200, gin.H{"msg": " Menu change success"})
+		p.releaseOrder(store, orderList) //변경 전 메뉴 수량 복구, 주문한 날짜 기준
+		p.publishOrder(orderList.ID, pubsub.EventUpdated, orderList.State, gin.H{"Items": []model.OrderItem{item}, "Price": item.Price + orderList.DeliveryFee})
+		p.publishKitchenUpdate(orderList.ID)
+		c.JSON(200, gin.H{"msg": " Menu change success", "Item": item, "Price": item.Price + orderList.DeliveryFee})
 		c.Next()
 	}
 	c.Next()
@@ -257,22 +685,28 @@ func (p *Controller) ChangeMenu(c *gin.Context) {
 
 // GetAllOrderList godoc
 // @Summary call GetAllOrderList, return OrderList by json.
-// @Description 전체 주문 내역 조회(주문자 수행)
+// @Description 주문 내역 조회, 주문 메뉴별 선택 옵션 및 가격 포함, 피주문자는 본인 사업장 주문만(주문자/피주문자 수행)
 // @name GetAllOrderList
 // @Accept  json
 // @Produce  json
+// @Param storeId query string false "storeId"
+// @Param pnum query string false "pnum"
 // @Router /customer/getAllOrderList [get]
+// @Router /seller/getOrderList [get]
 // @Success 200 {object} Controller
 func (p *Controller) GetAllOrderList(c *gin.Context) {
-	r, _ := model.NewModel()
-
-	orders := r.GetAllOrderList()
-	for _, order := range orders {
-		if order == (model.OrderList{}) { //주문 내역 없으면 null
-			p.RespError(c, nil, http.StatusUnprocessableEntity, "Null", nil)
+	var storeID primitive.ObjectID
+	if id, ok := c.Get("storeId"); ok { //피주문자는 본인 사업장
+		storeID = id.(primitive.ObjectID)
+	} else if sStoreID := c.Query("storeId"); len(sStoreID) > 0 {
+		id, err := primitive.ObjectIDFromHex(sStoreID)
+		if err != nil {
+			p.RespError(c, nil, http.StatusUnprocessableEntity, "invalid storeId", nil)
 			return
 		}
+		storeID = id
 	}
+	orders := p.md.GetAllOrderList(storeID, c.Query("pnum"))
 	c.JSON(200, gin.H{"Menu List": orders})
 	c.Next()
 }
@@ -281,35 +715,58 @@ func (p *Controller) GetAllOrderList(c *gin.Context) {
 
 // UpdateMenu godoc
 // @Summary call UpdateMenu, return "Menu change success" by json.
-// @Description 메뉴판 수정 기능(피주문자가 수행)
+// @Description 메뉴판 수정 기능, 입력한 항목만 변경(피주문자가 수행)
 // @name UpdateMenu
 // @Accept  json
 // @Produce  json
-// @Param menu path string true "menu"
-// @Param price path string true "price"
-// @Param recommend path string true "recommend"
+// @Param menuId path string false "menuId"
+// @Param menu path string false "menu"
+// @Param price path string false "price"
+// @Param recommend path string false "recommend"
+// @Param category path string false "category"
+// @Param description path string false "description"
+// @Param image path string false "image"
+// @Param origin path string false "origin (재료:원산지)"
+// @Param spiciness path int false "spiciness (0~5)"
+// @Param allergens path string false "allergens"
+// @Param calories path int false "calories"
 // @Router /seller/updateMenu [put]
+// @Router /seller/updateMenu [patch]
 // @Success 200 {object} Controller
 func (p *Controller) UpdateMenu(c *gin.Context) {
+	sMenuID := c.PostForm("menuId")
 	menuName := c.PostForm("menu")
-	sPrice := c.PostForm("price")
-	sRecommend := c.PostForm("recommend")
 
-	if len(menuName) <= 0 || len(sPrice) <= 0 {
+	if len(sMenuID) <= 0 && len(menuName) <= 0 {
 		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
 		return
 	}
 
-	burger, _ := p.md.GetMenu("menu", menuName) //메뉴이름으로 메뉴 정보 가져오기
-	if burger == (model.BurgerKing{}) {
+	storeID := sellerStoreID(c)
+	var burger model.BurgerKing
+	var err error
+	if len(sMenuID) > 0 { //메뉴 고유 번호 우선
+		menuID, idErr := primitive.ObjectIDFromHex(sMenuID)
+		if idErr != nil {
+			p.RespError(c, nil, http.StatusUnprocessableEntity, "invalid menuId", nil)
+			return
+		}
+		burger, err = p.md.GetMenuByID(menuID)
+	} else {
+		burger, err = p.md.GetMenu(storeID, "menu", menuName) //메뉴이름으로 메뉴 정보 가져오기
+	}
+	if err != nil || burger.StoreID != storeID { //다른 사업장 메뉴는 수정 불가
 		p.RespError(c, nil, http.StatusUnprocessableEntity, " Can`t find that menu", nil)
 		return
 	}
 
-	price, _ := strconv.Atoi(sPrice)
-	recommend, _ := strconv.Atoi(sRecommend)
+	patch, err := parseMenuPatch(c)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+		return
+	}
 
-	if err := p.md.UpdateMenu(menuName, price, recommend); err != nil {
+	if err := p.md.UpdateMenu(storeID, burger.ID, patch); err != nil {
 		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
 		return
 	}
@@ -320,7 +777,7 @@ func (p *Controller) UpdateMenu(c *gin.Context) {
 
 // DeleteMenu godoc
 // @Summary call DeleteMenu, return "Delete menu success" by json.
-// @Description 메뉴판 삭제 기능(피주문자가 수행)
+// @Description 메뉴판 삭제 기능, 안보임 처리 후 복구 가능(피주문자가 수행)
 // @name DeleteMenu
 // @Accept  json
 // @Produce  json
@@ -335,7 +792,8 @@ func (p *Controller) DeleteMenu(c *gin.Context) {
 		return
 	}
 
-	if err := p.md.DeleteMenu(menuName); err != nil {
+	deletedAt := time.Now().Format("2006-01-02 15:04:05")
+	if err := p.md.DeleteMenu(sellerStoreID(c), menuName, c.GetString("user"), deletedAt); err != nil {
 		p.RespError(c, nil, http.StatusUnprocessableEntity, "Menu delete Fail!", nil)
 		return
 	}
@@ -345,34 +803,129 @@ func (p *Controller) DeleteMenu(c *gin.Context) {
 
 }
 
+// GetDeletedMenu godoc
+// @Summary call GetDeletedMenu, return deleted BurgerKing menu by json.
+// @Description 삭제된 메뉴 리스트 조회(피주문자가 수행)
+// @name GetDeletedMenu
+// @Accept  json
+// @Produce  json
+// @Router /seller/getDeletedMenu [get]
+// @Success 200 {object} Controller
+func (p *Controller) GetDeletedMenu(c *gin.Context) {
+	burgers, err := p.md.GetDeletedMenu(sellerStoreID(c))
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get deleted menu", err.Error())
+		return
+	}
+
+	c.JSON(200, gin.H{"Menu List": burgers})
+	c.Next()
+}
+
+// RestoreMenu godoc
+// @Summary call RestoreMenu, return "Restore menu success" by json.
+// @Description 삭제된 메뉴 복구 기능(피주문자가 수행)
+// @name RestoreMenu
+// @Accept  json
+// @Produce  json
+// @Param menu path string true "menu"
+// @Router /seller/restore/:menu [put]
+// @Success 200 {object} Controller
+func (p *Controller) RestoreMenu(c *gin.Context) {
+	menuName := c.Param("menu")
+
+	if err := p.md.RestoreMenu(sellerStoreID(c), menuName); err == model.ErrMenuExists {
+		p.RespError(c, nil, http.StatusConflict, "Same menu name is already in menu", nil)
+		return
+	} else if err == model.ErrMenuNotFound {
+		p.RespError(c, nil, http.StatusNotFound, "There is no deleted menu "+menuName, nil)
+		return
+	} else if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Menu restore Fail!", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Restore menu success"})
+	c.Next()
+}
+
+// PurgeMenu godoc
+// @Summary call PurgeMenu, return "Purge menu success" by json.
+// @Description 삭제된 메뉴 영구 삭제 기능(관리자가 수행)
+// @name PurgeMenu
+// @Accept  json
+// @Produce  json
+// @Param menu path string true "menu"
+// @Param storeId query string true "storeId"
+// @Router /admin/purgeMenu/:menu [delete]
+// @Success 200 {object} Controller
+func (p *Controller) PurgeMenu(c *gin.Context) {
+	menuName := c.Param("menu")
+	storeID, err := primitive.ObjectIDFromHex(c.Query("storeId"))
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "invalid storeId", nil)
+		return
+	}
+
+	count, err := p.md.PurgeMenu(storeID, menuName)
+	if err == model.ErrMenuNotFound { //삭제 처리된 메뉴만 영구 삭제 가능
+		p.RespError(c, nil, http.StatusNotFound, "There is no deleted menu "+menuName, nil)
+		return
+	} else if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Menu purge Fail!", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Purge menu success", "Purged": count})
+	c.Next()
+}
+
 // RegisterMenu godoc
 // @Summary call RegisterMenu, return ""Register menu Success" by json.
-// @Description 신규메뉴 등록기능(피주문자가 수행)
+// @Description 신규메뉴 등록기능, 카테고리/설명/원산지/맵기/알레르기/열량 선택 입력(피주문자가 수행)
 // @name RegisterMenu
 // @Accept  json
 // @Produce  json
 // @Param menu path string true "menu"
 // @Param price path string true "price"
 // @Param recommend path string true "recommend"
+// @Param category path string false "category"
+// @Param description path string false "description"
+// @Param image path string false "image"
+// @Param origin path string false "origin (재료:원산지)"
+// @Param spiciness path int false "spiciness (0~5)"
+// @Param allergens path string false "allergens"
+// @Param calories path int false "calories"
 // @Router /seller/register [post]
 // @Success 200 {object} Controller
 func (p *Controller) RegisterMenu(c *gin.Context) {
 
 	menuName := c.PostForm("menu")
 	sPrice := c.PostForm("price")
-	sRecommend := c.PostForm("recommend")
 
 	if len(menuName) <= 0 || len(sPrice) <= 0 {
 		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
 		return
 	}
 
-	price, _ := strconv.Atoi(sPrice)
-	recommend, _ := strconv.Atoi(sRecommend)
-	grade := 0 //최초 평점은 0점
+	storeID := sellerStoreID(c)
+	if _, err := p.md.GetMenu(storeID, "menu", menuName); err == nil { //사업장에 같은 이름의 메뉴가 있으면
+		p.RespError(c, nil, http.StatusConflict, "Same menu name is already in menu", nil)
+		return
+	}
+
+	patch, err := parseMenuPatch(c)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+		return
+	}
+
+	grade := 0.0 //최초 평점은 0점, 이후 리뷰 작성시 갱신
 	releaseTime := time.Now().Format("2006-01-02 15:04:05")
 
-	req := model.BurgerKing{Menu: menuName, Price: price, Recommend: recommend, Grade: grade, ReleaseTime: releaseTime}
+	req := model.BurgerKing{StoreID: storeID, Menu: menuName, Grade: grade, ReviewCount: 0, ReleaseTime: releaseTime, Status: model.MenuOrderable, Type: model.MenuSingle, Origins: []model.Origin{}, Allergens: []string{}}
+	req.Currency, _ = p.taxDefaults()
+	patch.Apply(&req)
 
 	if err := p.md.CreateMenu(req); err != nil {
 		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
@@ -385,28 +938,81 @@ func (p *Controller) RegisterMenu(c *gin.Context) {
 
 // UpdateOrderState godoc
 // @Summary call UpdateOrderState, return "State change success" by json.
-// @Description 주문내역 조회 및 상태 변경(피주문자가 수행)
+// @Description 주문내역 조회 및 상태 변경, 수령 방법별 순서대로만 변경 ex. 포장 주문은 조리중 -> 픽업대기 -> 픽업완료(피주문자가 수행)
 // @name UpdateOrderState
 // @Accept  json
 // @Produce  json
-// @Param menu path string true "menu"
+// @Param orderId path string false "orderId"
+// @Param menu path string false "menu"
 // @Param state path string true "state"
 // @Router /seller/updateOrderState [put]
 // @Success 200 {object} Controller
 func (p *Controller) UpdateOrderState(c *gin.Context) {
+	sOrderID := c.PostForm("orderId")
 	menuName := c.PostForm("menu")
 	state := c.PostForm("state")
-	if len(menuName) <= 0 || len(state) <= 0 {
+	if (len(sOrderID) <= 0 && len(menuName) <= 0) || len(state) <= 0 {
 		p.RespError(c, nil, http.StatusUnprocessableEntity, "Fail,parameter not found", nil)
 		return
 	}
 
-	if err := p.md.UpdateState(menuName, state); err != nil {
+	storeID := sellerStoreID(c)
+	var orderList model.OrderList
+	var err error
+	if len(sOrderID) > 0 { //주문 번호가 있으면 해당 주문만 변경
+		orderID, idErr := primitive.ObjectIDFromHex(sOrderID)
+		if idErr != nil {
+			p.RespError(c, nil, http.StatusUnprocessableEntity, "invalid orderId", nil)
+			return
+		}
+		orderList, err = p.md.GetOrder(orderID)
+	} else {
+		orderList, err = p.md.GetOrderListByMenu(storeID, "menu", menuName)
+	}
+	if err != nil || orderList.StoreID != storeID {
 		p.RespError(c, nil, http.StatusUnprocessableEntity, "Fail,parameter not found", nil)
 		return
 	}
 
+	if err := orderList.CheckStateChange(state); err != nil { //수령 방법별 상태 순서 확인
+		p.RespError(c, nil, http.StatusConflict, "Can`t change order state", err.Error())
+		return
+	}
+	if err := p.md.UpdateStateByID(storeID, orderList.ID, orderList.State, state); err != nil {
+		p.RespError(c, nil, http.StatusConflict, "Can`t change order state", err.Error())
+		return
+	}
+	if d := orderList.Delivery; d != nil && d.DeliveredAt.IsZero() && state == model.StateDelivered { //사업장에서 배달 완료 처리시 배달원 복구
+		if err := p.md.CompleteDelivery(d.RiderID, orderList.ID, time.Now()); err != nil {
+			p.RespError(c, nil, http.StatusInternalServerError, "Failed to complete delivery", err.Error())
+			return
+		}
+	}
+
+	p.publishOrder(orderList.ID, pubsub.EventState, state, nil)
+	orderList.State = state
+	p.publishKitchen(orderList, pubsub.EventState, nil)
+	if orderList.Completed() { //수령 완료시 결제 매입
+		if err := p.settlePayment(orderList); err != nil {
+			fmt.Println("Failed to settle payment", orderList.ID.Hex(), err)
+		}
+		p.earnLoyalty(orderList)
+	}
 	fmt.Println("State changed")
-	c.JSON(200, gin.H{"msg": "State change success", menuName: state})
+	key := sOrderID
+	if len(key) <= 0 {
+		key = menuName
+	}
+	c.JSON(200, gin.H{"msg": "State change success", key: state})
 	c.Next()
 }
+
+// 주문 메뉴 목록에 해당 메뉴가 있는지 확인
+func containsMenu(menus []string, menuName string) bool {
+	for _, m := range menus {
+		if m == menuName {
+			return true
+		}
+	}
+	return false
+}
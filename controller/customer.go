@@ -0,0 +1,269 @@
+package controller
+
+// /customer.go : 고객 정보, 배달 주소록 관리 및 주문시 배달 주소 선택
+import (
+	"fmt"
+	"lecture/oos/model"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 요청의 주소 항목 읽기, 도로명 주소는 필수
+func parseAddress(c *gin.Context) (model.Address, error) {
+	addr := model.Address{
+		Label:      c.PostForm("label"),
+		Street:     c.PostForm("street"),
+		Detail:     c.PostForm("detail"),
+		PostalCode: c.PostForm("postalCode"),
+		Notes:      c.PostForm("notes"),
+	}
+	if len(addr.Street) <= 0 {
+		return addr, fmt.Errorf("street not found")
+	}
+	if lat, err := formFloat(c, "lat"); err != nil {
+		return addr, err
+	} else if lat != nil {
+		addr.Lat = *lat
+	}
+	if lng, err := formFloat(c, "lng"); err != nil {
+		return addr, err
+	} else if lng != nil {
+		addr.Lng = *lng
+	}
+	return addr, nil
+}
+
+// 배달 주문 주소 선택, 주소록 번호 > 직접 입력 주소 > 기본 주소 순. 주소록 주소는 주문에 복사
+// 직접 입력 주소는 좌표가 있으면 좌표와 함께 복사
+func (p *Controller) deliveryAddress(pnum, sAddressID, address string, lat, lng *float64) (*model.Address, string, error) {
+	if len(sAddressID) > 0 {
+		addressID, err := primitive.ObjectIDFromHex(sAddressID)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid addressId")
+		}
+		customer, err := p.md.GetCustomer(pnum)
+		if err != nil {
+			return nil, "", model.ErrAddressNotFound
+		}
+		addr, err := customer.FindAddress(addressID)
+		if err != nil {
+			return nil, "", err
+		}
+		return &addr, addr.String(), nil
+	}
+	if len(address) > 0 {
+		if lat != nil && lng != nil {
+			return &model.Address{Street: address, Lat: *lat, Lng: *lng}, address, nil
+		}
+		return nil, address, nil
+	}
+	if customer, err := p.md.GetCustomer(pnum); err == nil {
+		if addr, err := customer.DefaultAddress(); err == nil {
+			return &addr, addr.String(), nil
+		}
+	}
+	return nil, "", fmt.Errorf("address not found")
+}
+
+// GetProfile godoc
+// @Summary call GetProfile, return Customer by json.
+// @Description 고객 정보 및 저장된 배달 주소 조회(주문자가 수행)
+// @name GetProfile
+// @Accept  json
+// @Produce  json
+// @Param pnum query string true "pnum"
+// @Router /customer/getProfile [get]
+// @Success 200 {object} Controller
+func (p *Controller) GetProfile(c *gin.Context) {
+	pnum := c.Query("pnum")
+	if len(pnum) <= 0 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	customer, err := p.md.GetCustomer(pnum)
+	if err == model.ErrCustomerNotFound {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find your profile", nil)
+		return
+	} else if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get profile", err.Error())
+		return
+	}
+
+	c.JSON(200, customer)
+	c.Next()
+}
+
+// UpdateProfile godoc
+// @Summary call UpdateProfile, return "Profile saved" by json.
+// @Description 고객 정보 등록 및 수정(주문자가 수행)
+// @name UpdateProfile
+// @Accept  json
+// @Produce  json
+// @Param pnum path string true "pnum"
+// @Param name path string true "name"
+// @Router /customer/updateProfile [put]
+// @Success 200 {object} Controller
+func (p *Controller) UpdateProfile(c *gin.Context) {
+	pnum := c.PostForm("pnum")
+	name := c.PostForm("name")
+	if len(pnum) <= 0 || len(name) <= 0 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	if err := p.md.SaveCustomer(pnum, name, time.Now().Format("2006-01-02 15:04:05")); err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to save profile", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Profile saved"})
+	c.Next()
+}
+
+// AddAddress godoc
+// @Summary call AddAddress, return "Address added" by json.
+// @Description 배달 주소 저장, 처음 저장한 주소는 기본 주소(주문자가 수행)
+// @name AddAddress
+// @Accept  json
+// @Produce  json
+// @Param pnum path string true "pnum"
+// @Param label path string false "label (ex. 집, 회사)"
+// @Param street path string true "street"
+// @Param detail path string false "detail"
+// @Param postalCode path string false "postalCode"
+// @Param lat path number false "lat"
+// @Param lng path number false "lng"
+// @Param notes path string false "notes (배달 요청 사항)"
+// @Param default path string false "default (true/false)"
+// @Router /customer/addAddress [post]
+// @Success 200 {object} Controller
+func (p *Controller) AddAddress(c *gin.Context) {
+	pnum := c.PostForm("pnum")
+	if len(pnum) <= 0 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+	addr, err := parseAddress(c)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+		return
+	}
+	makeDefault, _ := strconv.ParseBool(c.PostForm("default"))
+
+	addressID, err := p.md.AddAddress(pnum, addr, makeDefault, time.Now().Format("2006-01-02 15:04:05"))
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to add address", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Address added", "Address ID": addressID.Hex()})
+	c.Next()
+}
+
+// UpdateAddress godoc
+// @Summary call UpdateAddress, return "Address updated" by json.
+// @Description 저장된 배달 주소 수정, 이전 주문의 주소는 변경되지 않음(주문자가 수행)
+// @name UpdateAddress
+// @Accept  json
+// @Produce  json
+// @Param pnum path string true "pnum"
+// @Param addressId path string true "addressId"
+// @Param label path string false "label"
+// @Param street path string true "street"
+// @Param detail path string false "detail"
+// @Param postalCode path string false "postalCode"
+// @Param lat path number false "lat"
+// @Param lng path number false "lng"
+// @Param notes path string false "notes"
+// @Router /customer/updateAddress [put]
+// @Success 200 {object} Controller
+func (p *Controller) UpdateAddress(c *gin.Context) {
+	pnum := c.PostForm("pnum")
+	addressID, err := primitive.ObjectIDFromHex(c.PostForm("addressId"))
+	if len(pnum) <= 0 || err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+	addr, err := parseAddress(c)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+		return
+	}
+	addr.ID = addressID
+
+	if err := p.md.UpdateAddress(pnum, addr); err == model.ErrAddressNotFound {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find that address", nil)
+		return
+	} else if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to update address", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Address updated"})
+	c.Next()
+}
+
+// DeleteAddress godoc
+// @Summary call DeleteAddress, return "Address deleted" by json.
+// @Description 저장된 배달 주소 삭제(주문자가 수행)
+// @name DeleteAddress
+// @Accept  json
+// @Produce  json
+// @Param addressId path string true "addressId"
+// @Param pnum query string true "pnum"
+// @Router /customer/deleteAddress/:addressId [delete]
+// @Success 200 {object} Controller
+func (p *Controller) DeleteAddress(c *gin.Context) {
+	pnum := c.Query("pnum")
+	addressID, err := primitive.ObjectIDFromHex(c.Param("addressId"))
+	if len(pnum) <= 0 || err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	if err := p.md.DeleteAddress(pnum, addressID); err == model.ErrAddressNotFound {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find that address", nil)
+		return
+	} else if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to delete address", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Address deleted"})
+	c.Next()
+}
+
+// SetDefaultAddress godoc
+// @Summary call SetDefaultAddress, return "Default address changed" by json.
+// @Description 기본 배달 주소 변경, 주소 없이 주문하면 기본 주소로 배달(주문자가 수행)
+// @name SetDefaultAddress
+// @Accept  json
+// @Produce  json
+// @Param pnum path string true "pnum"
+// @Param addressId path string true "addressId"
+// @Router /customer/setDefaultAddress [put]
+// @Success 200 {object} Controller
+func (p *Controller) SetDefaultAddress(c *gin.Context) {
+	pnum := c.PostForm("pnum")
+	addressID, err := primitive.ObjectIDFromHex(c.PostForm("addressId"))
+	if len(pnum) <= 0 || err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	if err := p.md.SetDefaultAddress(pnum, addressID); err == model.ErrAddressNotFound {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find that address", nil)
+		return
+	} else if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to change default address", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Default address changed"})
+	c.Next()
+}
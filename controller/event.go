@@ -0,0 +1,116 @@
+package controller
+
+// /event.go : 주문 이벤트 발행 및 Server-Sent Events로 실시간 주문 상태, 주방 화면 전달
+import (
+	"context"
+	"io"
+	"lecture/oos/model"
+	"lecture/oos/pubsub"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 연결 유지를 위한 빈 메세지 전송 주기
+const heartbeatInterval = 15 * time.Second
+
+type responseWriterKey struct{}
+
+// 서버의 원래 ResponseWriter를 요청 context에 저장, gin ResponseWriter는 Unwrap이 없어 ResponseController를 사용할 수 없음
+func WithResponseWriter(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), responseWriterKey{}, w)))
+	})
+}
+
+// SSE 연결은 서버 WriteTimeout 대신 다음 heartbeat까지 쓰기 기한 연장
+func extendWriteDeadline(c *gin.Context) {
+	if w, ok := c.Request.Context().Value(responseWriterKey{}).(http.ResponseWriter); ok {
+		http.NewResponseController(w).SetWriteDeadline(time.Now().Add(2 * heartbeatInterval))
+	}
+}
+
+// SSE 응답 헤더
+func sseHeaders(c *gin.Context) {
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no") //프록시 버퍼링 해제
+	extendWriteDeadline(c)
+}
+
+// 구독한 이벤트를 SSE로 전달, 연결이 끊기거나 서버가 종료되거나 stop이 true면 종료
+func streamEvents(c *gin.Context, events <-chan pubsub.Event, stop func(pubsub.Event) bool) {
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	c.Stream(func(w io.Writer) bool {
+		extendWriteDeadline(c)
+		select {
+		case <-c.Request.Context().Done(): //구독자 연결 종료
+			return false
+		case ev, ok := <-events:
+			if !ok { //서버 종료
+				return false
+			}
+			c.SSEvent(ev.Type, ev)
+			return stop == nil || !stop(ev)
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": ping\n\n")
+			return err == nil
+		}
+	})
+}
+
+// 주문 구독자에게 이벤트 발행
+func (p *Controller) publishOrder(orderID primitive.ObjectID, evType, state string, data interface{}) {
+	p.ps.Publish(pubsub.OrderTopic(orderID.Hex()), pubsub.Event{Type: evType, OrderID: orderID.Hex(), State: state, Data: data})
+}
+
+// 사업장 주방 화면에 이벤트 발행, 결제 대기 및 주방 전달 전 예약 주문은 제외
+func (p *Controller) publishKitchen(order model.OrderList, evType string, alert *pubsub.Alert) {
+	if order.State == model.StateScheduled || order.State == model.StatePendingPayment {
+		return
+	}
+	p.ps.Publish(pubsub.StoreTopic(order.StoreID.Hex()), pubsub.Event{Type: evType, OrderID: order.ID.Hex(), State: order.State, Data: order, Alert: alert})
+}
+
+// SubscribeOrder godoc
+// @Summary call SubscribeOrder, return order events by text/event-stream.
+// @Description 주문 상태 변경, 메뉴 변경, 배달원 배차 및 위치를 실시간 전달(SSE), 처음에 현재 상태를 보내고 주문이 완료, 취소 또는 거절되면 종료(주문자가 수행)
+// @name SubscribeOrder
+// @Accept  json
+// @Produce  text/event-stream
+// @Param orderId path string true "orderId"
+// @Param pnum query string true "pnum"
+// @Router /customer/subscribeOrder/:orderId [get]
+// @Success 200 {object} Controller
+func (p *Controller) SubscribeOrder(c *gin.Context) {
+	pnum := c.Query("pnum")
+	orderID, err := primitive.ObjectIDFromHex(c.Param("orderId"))
+	if len(pnum) <= 0 || err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	//구독 후 주문을 조회해 조회와 구독 사이의 변경도 전달
+	events, unsubscribe := p.ps.Subscribe(pubsub.OrderTopic(orderID.Hex()))
+	defer unsubscribe()
+	order, err := p.md.GetOrder(orderID)
+	if err != nil || order.Pnum != pnum {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find your order", nil)
+		return
+	}
+
+	sseHeaders(c)
+	c.SSEvent(pubsub.EventState, pubsub.Event{Type: pubsub.EventState, OrderID: orderID.Hex(), State: order.State, At: time.Now()})
+	if order.Completed() || order.Cancelled() { //수령 완료, 취소 및 거절된 주문은 더 이상 변경 없음
+		return
+	}
+	streamEvents(c, events, func(ev pubsub.Event) bool {
+		if len(ev.State) > 0 {
+			order.State = ev.State
+		}
+		return order.Completed() || order.Cancelled()
+	})
+}
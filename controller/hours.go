@@ -0,0 +1,96 @@
+package controller
+
+// /hours.go : 사업장 영업 시간, 휴무일, 주문 일시 중지 확인 및 예약 주문 시간 처리
+import (
+	"fmt"
+	"lecture/oos/model"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 주문 접수 가능 여부 확인 후 메뉴를 받을 시간 반환, 예약 시간이 없으면 즉시 주문. 실패시 응답 status와 에러 반환
+func (p *Controller) acceptOrder(store model.Store, now time.Time, sScheduled string) (time.Time, int, error) {
+	if store.Paused {
+		return now, http.StatusConflict, fmt.Errorf("%w, %s", model.ErrStorePaused, store.PauseReason)
+	}
+
+	if len(sScheduled) <= 0 {
+		if err := store.OpenAt(now); err != nil {
+			if next, ok := store.NextOpen(now); ok {
+				return now, http.StatusConflict, fmt.Errorf("%w, opens at %s. You can order in advance with scheduledTime", err, next.Format("2006-01-02 15:04"))
+			}
+			return now, http.StatusConflict, err
+		}
+		return now, http.StatusOK, nil
+	}
+
+	//예약 시간은 사업장 시간대 기준 ex. 2026-10-20 12:00
+	at, err := time.ParseInLocation("2006-01-02 15:04", sScheduled, store.Location())
+	if err != nil {
+		return now, http.StatusUnprocessableEntity, fmt.Errorf("scheduledTime must be YYYY-MM-DD HH:MM")
+	}
+	if lead := store.MinLeadTime(p.cf.Schedule.LeadTime); at.Before(now.Add(lead)) { //최소 예약 시간 이후만 가능
+		return now, http.StatusUnprocessableEntity, fmt.Errorf("scheduledTime must be at least %d minutes later", int(lead.Minutes()))
+	}
+	if err := store.OpenAt(at); err != nil {
+		return now, http.StatusConflict, fmt.Errorf("%w at scheduledTime %s", err, sScheduled)
+	}
+	return at, http.StatusOK, nil
+}
+
+// 주문 메뉴를 받을 시간, 예약 주문이면 예약 시간
+func orderAt(store model.Store, order model.OrderList, now time.Time) time.Time {
+	if at, err := time.ParseInLocation("2006-01-02 15:04:05", order.ScheduledTime, store.Location()); err == nil {
+		return at
+	}
+	return now
+}
+
+// PauseOrders godoc
+// @Summary call PauseOrders, return store paused state by json.
+// @Description 주문 일시 중지 및 재개 기능, 중지중에는 신규 주문 및 예약 주문 불가(피주문자가 수행)
+// @name PauseOrders
+// @Accept  json
+// @Produce  json
+// @Param paused path string true "paused (true/false)"
+// @Param reason path string false "reason"
+// @Router /seller/pauseOrders [put]
+// @Success 200 {object} Controller
+func (p *Controller) PauseOrders(c *gin.Context) {
+	paused, err := strconv.ParseBool(c.PostForm("paused"))
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "paused must be true or false", nil)
+		return
+	}
+	reason := c.PostForm("reason")
+
+	if err := p.md.SetStorePaused(sellerStoreID(c), paused, reason); err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Fail,parameter not found", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"msg": "Store pause change success", "Paused": paused})
+	c.Next()
+}
+
+// GetScheduledOrders godoc
+// @Summary call GetScheduledOrders, return scheduled OrderList by json.
+// @Description 주방 전달 전 대기중인 예약 주문 조회, 전달 시간순(피주문자가 수행)
+// @name GetScheduledOrders
+// @Accept  json
+// @Produce  json
+// @Router /seller/getScheduledOrders [get]
+// @Success 200 {object} Controller
+func (p *Controller) GetScheduledOrders(c *gin.Context) {
+	orders, err := p.md.GetScheduledOrders(sellerStoreID(c))
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get scheduled orders", err.Error())
+		return
+	}
+
+	c.JSON(200, gin.H{"Order List": orders})
+	c.Next()
+}
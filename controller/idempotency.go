@@ -0,0 +1,161 @@
+package controller
+
+// /idempotency.go : Idempotency-Key 헤더로 재시도 요청에 처음 응답 반환
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"lecture/oos/model"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const multipartMemory = 32 << 20 //multipart 파싱시 메모리에 두는 최대 크기, gin 기본값과 같음
+
+// 처음 응답 body를 함께 기록하는 ResponseWriter
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// 요청 내용 hash, query와 form은 파싱한 값을 key 순서로 정렬해 비교
+// multipart는 요청마다 달라지는 boundary 대신 값과 파일 이름, 파일 내용 hash로 비교, 그 외 body는 원본 비교
+func requestHash(r *http.Request, body []byte) (string, error) {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s %s?%s\n", r.Method, r.URL.Path, r.URL.Query().Encode())
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/x-www-form-urlencoded":
+		if err := r.ParseForm(); err != nil {
+			return "", err
+		}
+		fmt.Fprintln(h, r.PostForm.Encode())
+	case "multipart/form-data":
+		if err := r.ParseMultipartForm(multipartMemory); err != nil {
+			return "", err
+		}
+		fmt.Fprintln(h, url.Values(r.MultipartForm.Value).Encode())
+		fields := []string{}
+		for field := range r.MultipartForm.File {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			for _, fh := range r.MultipartForm.File[field] {
+				f, err := fh.Open()
+				if err != nil {
+					return "", err
+				}
+				sum := sha256.New()
+				_, err = io.Copy(sum, f)
+				f.Close()
+				if err != nil {
+					return "", err
+				}
+				fmt.Fprintf(h, "%s=%s:%x\n", field, fh.Filename, sum.Sum(nil))
+			}
+		}
+	default:
+		h.Write(body)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// Idempotency-Key 헤더가 있으면 같은 키의 재시도에 처음 응답 반환, 같은 키로 다른 요청이면 409
+// 서버 에러(5xx)는 저장하지 않아 같은 키로 다시 시도 가능
+func (p *Controller) Idempotent() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idemKey := c.GetHeader("Idempotency-Key")
+		if len(idemKey) <= 0 {
+			c.Next()
+			return
+		}
+		if len(idemKey) > 255 {
+			p.RespError(c, nil, http.StatusUnprocessableEntity, "Idempotency-Key is too long", nil)
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			p.RespError(c, nil, http.StatusBadRequest, "Failed to read request", err.Error())
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		hash, err := requestHash(c.Request, body) //파싱한 form은 이후 핸들러에서 그대로 사용
+		if err != nil {
+			p.RespError(c, nil, http.StatusBadRequest, "Failed to read request", err.Error())
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body)) //이후 핸들러에서 다시 읽기
+
+		ttl := time.Duration(p.cf.Idempotency.TTL) * time.Hour
+		if ttl <= 0 {
+			ttl = 24 * time.Hour
+		}
+		lease := time.Duration(p.cf.Idempotency.Lease) * time.Second
+		if lease <= 0 {
+			lease = time.Minute
+		}
+		now := time.Now()
+		user, pnum := c.GetString("user"), c.PostForm("pnum") //다른 고객의 같은 키와 섞이지 않게 계정과 고객 번호로 구분
+		if len(user) <= 0 && len(pnum) <= 0 {
+			p.RespError(c, nil, http.StatusUnprocessableEntity, "Idempotency-Key needs Authorization or pnum", nil)
+			return
+		}
+		key := fmt.Sprintf("%s:%s:%s:%s", user, pnum, c.FullPath(), idemKey)
+
+		saved, err := p.md.ReserveIdempotencyKey(key, hash, now, now.Add(lease), now.Add(ttl))
+		if err == model.ErrIdempotencyKeyExists {
+			switch {
+			case saved.RequestHash != hash:
+				p.RespError(c, nil, http.StatusConflict, "Idempotency-Key was already used for a different request", nil)
+			case saved.Status != model.IdempotencyDone:
+				p.RespError(c, nil, http.StatusConflict, "A request with this Idempotency-Key is still in progress", nil)
+			default:
+				c.Header("Idempotent-Replayed", "true")
+				c.Data(saved.RespStatus, "application/json; charset=utf-8", saved.RespBody)
+				c.Abort()
+			}
+			return
+		} else if err != nil {
+			p.RespError(c, nil, http.StatusInternalServerError, "Failed to check Idempotency-Key", err.Error())
+			return
+		}
+
+		defer func() { //응답을 저장하지 못했으면(5xx, panic) 키를 풀어 다시 시도 가능
+			if saved.Status != model.IdempotencyDone {
+				p.md.ReleaseIdempotencyKey(key)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		if status := recorder.Status(); status < http.StatusInternalServerError {
+			if err := p.md.SaveIdempotentResponse(key, status, recorder.body.Bytes()); err != nil {
+				fmt.Println("Failed to save idempotent response", key, err)
+				return
+			}
+			saved.Status = model.IdempotencyDone
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package controller
+
+// /image.go : 리뷰 이미지 업로드 검증, 썸네일 생성 및 이미지 제공
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif" //gif 디코더 등록
+	"image/jpeg"
+	_ "image/png" //png 디코더 등록
+	"io"
+	"lecture/oos/model"
+	"mime/multipart"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 업로드 허용 이미지 타입과 저장 확장자
+var imageExts = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// 검증이 끝난 업로드 이미지
+type uploadImage struct {
+	data        []byte
+	contentType string
+	thumb       []byte //jpeg 썸네일
+}
+
+// 멀티파트 요청의 images 파일들을 크기, 타입 검증 후 썸네일 생성
+func (p *Controller) readImages(c *gin.Context) ([]uploadImage, error) {
+	form, err := c.MultipartForm()
+	if err == http.ErrNotMultipart { //멀티파트 요청이 아니면 이미지 없음
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	files := form.File["images"]
+	if len(files) > p.cf.Storage.MaxImages {
+		return nil, fmt.Errorf("You can upload up to %d images", p.cf.Storage.MaxImages)
+	}
+
+	images := []uploadImage{}
+	for _, fh := range files {
+		img, err := p.readImage(fh)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, img)
+	}
+	return images, nil
+}
+
+func (p *Controller) readImage(fh *multipart.FileHeader) (uploadImage, error) {
+	if fh.Size > p.cf.Storage.MaxImageSize {
+		return uploadImage{}, fmt.Errorf("%s is larger than %d bytes", fh.Filename, p.cf.Storage.MaxImageSize)
+	}
+	file, err := fh.Open()
+	if err != nil {
+		return uploadImage{}, err
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, p.cf.Storage.MaxImageSize+1))
+	if err != nil {
+		return uploadImage{}, err
+	} else if int64(len(data)) > p.cf.Storage.MaxImageSize {
+		return uploadImage{}, fmt.Errorf("%s is larger than %d bytes", fh.Filename, p.cf.Storage.MaxImageSize)
+	}
+
+	//클라이언트가 보낸 content-type이 아닌 실제 파일 내용으로 타입 확인
+	contentType := http.DetectContentType(data)
+	if _, ok := imageExts[contentType]; !ok {
+		return uploadImage{}, fmt.Errorf("%s is not allowed image type (%s)", fh.Filename, contentType)
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return uploadImage{}, fmt.Errorf("%s is broken image", fh.Filename)
+	}
+	var thumb bytes.Buffer
+	if err := jpeg.Encode(&thumb, thumbnail(src, p.cf.Storage.ThumbWidth), &jpeg.Options{Quality: 80}); err != nil {
+		return uploadImage{}, err
+	}
+
+	return uploadImage{data: data, contentType: contentType, thumb: thumb.Bytes()}, nil
+}
+
+// 가로 width 크기로 비율을 유지하며 축소, 영역 평균으로 샘플링
+func thumbnail(src image.Image, width int) image.Image {
+	b := src.Bounds()
+	if width <= 0 || b.Dx() <= width {
+		width = b.Dx()
+	}
+	height := b.Dy() * width / b.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := b.Min.Y + y*b.Dy()/height
+		y1 := b.Min.Y + (y+1)*b.Dy()/height
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < width; x++ {
+			x0 := b.Min.X + x*b.Dx()/width
+			x1 := b.Min.X + (x+1)*b.Dx()/width
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+
+			var r, g, bl, a, n uint32
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := src.At(sx, sy).RGBA()
+					r, g, bl, a, n = r+cr, g+cg, bl+cb, a+ca, n+1
+				}
+			}
+			i := dst.PixOffset(x, y)
+			dst.Pix[i+0] = uint8(r / n >> 8)
+			dst.Pix[i+1] = uint8(g / n >> 8)
+			dst.Pix[i+2] = uint8(bl / n >> 8)
+			dst.Pix[i+3] = uint8(a / n >> 8)
+		}
+	}
+	return dst
+}
+
+// 리뷰 이미지와 썸네일을 저장소에 저장, 실패시 저장된 파일 삭제
+func (p *Controller) saveImages(reviewID string, images []uploadImage) ([]model.ReviewImage, error) {
+	saved := []model.ReviewImage{}
+	for i, img := range images {
+		key := fmt.Sprintf("reviews/%s/%d%s", reviewID, i, imageExts[img.contentType])
+		thumbKey := fmt.Sprintf("reviews/%s/%d_thumb.jpg", reviewID, i)
+
+		if err := p.bs.Put(key, bytes.NewReader(img.data)); err != nil {
+			p.deleteImages(saved)
+			return nil, err
+		}
+		if err := p.bs.Put(thumbKey, bytes.NewReader(img.thumb)); err != nil {
+			p.bs.Delete(key)
+			p.deleteImages(saved)
+			return nil, err
+		}
+		saved = append(saved, model.ReviewImage{Key: key, ThumbKey: thumbKey, ContentType: img.contentType})
+	}
+	return saved, nil
+}
+
+func (p *Controller) deleteImages(images []model.ReviewImage) {
+	for _, img := range images {
+		if err := p.bs.Delete(img.Key); err != nil {
+			fmt.Println("Failed to delete image", img.Key, err)
+		}
+		if err := p.bs.Delete(img.ThumbKey); err != nil {
+			fmt.Println("Failed to delete image", img.ThumbKey, err)
+		}
+	}
+}
+
+// 응답용 이미지 url 설정
+func (p *Controller) setImageURLs(reviews []model.MenuReview) {
+	for i := range reviews {
+		for j := range reviews[i].Images {
+			img := &reviews[i].Images[j]
+			img.URL = p.cf.Storage.BaseURL + "/" + img.Key
+			img.ThumbURL = p.cf.Storage.BaseURL + "/" + img.ThumbKey
+		}
+	}
+}
+
+// GetImage godoc
+// @Summary call GetImage, return image file.
+// @Description 리뷰 이미지 및 썸네일 제공
+// @name GetImage
+// @Produce  png
+// @Produce  jpeg
+// @Param key path string true "key"
+// @Router /images/:key [get]
+// @Success 200 {object} Controller
+func (p *Controller) GetImage(c *gin.Context) {
+	key := c.Param("key")
+
+	file, contentType, err := p.bs.Get(key)
+	if err != nil {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find that image", nil)
+		return
+	}
+	defer file.Close()
+
+	if len(contentType) <= 0 {
+		contentType = "application/octet-stream"
+	}
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.DataFromReader(http.StatusOK, -1, contentType, file, nil)
+}
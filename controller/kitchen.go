@@ -0,0 +1,128 @@
+package controller
+
+// /kitchen.go : 사업장 주방 화면 실시간 주문 전달, 주문 확인 및 조리 단계 넘기기
+import (
+	"lecture/oos/model"
+	"lecture/oos/pubsub"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 주방 화면의 사업장 주문 조회
+func (p *Controller) kitchenOrder(c *gin.Context) (model.OrderList, bool) {
+	orderID, err := primitive.ObjectIDFromHex(c.PostForm("orderId"))
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return model.OrderList{}, false
+	}
+	order, err := p.md.GetOrder(orderID)
+	if err != nil || order.StoreID != sellerStoreID(c) {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find that order", nil)
+		return order, false
+	}
+	return order, true
+}
+
+// 변경된 주문을 다시 조회해 주방 화면에 전달, 확인할 때까지 알림음 반복
+func (p *Controller) publishKitchenUpdate(orderID primitive.ObjectID) {
+	if order, err := p.md.GetOrder(orderID); err == nil {
+		p.publishKitchen(order, pubsub.EventUpdated, &pubsub.Alert{Sound: pubsub.SoundModified, Repeat: true})
+	}
+}
+
+// KitchenStream godoc
+// @Summary call KitchenStream, return kitchen events by text/event-stream.
+// @Description 주방 화면 실시간 주문 전달(SSE), 처음에 처리중인 주문 목록(snapshot)을 보내고 새 주문, 주문 변경, 취소, 상태 변경을 알림음 정보와 함께 전달(피주문자가 수행)
+// @name KitchenStream
+// @Accept  json
+// @Produce  text/event-stream
+// @Router /seller/kitchenStream [get]
+// @Success 200 {object} Controller
+func (p *Controller) KitchenStream(c *gin.Context) {
+	storeID := sellerStoreID(c)
+
+	//구독 후 주문 목록을 조회해 조회와 구독 사이의 주문도 전달
+	events, unsubscribe := p.ps.Subscribe(pubsub.StoreTopic(storeID.Hex()))
+	defer unsubscribe()
+	orders, err := p.md.GetKitchenOrders(storeID)
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get orders", err.Error())
+		return
+	}
+
+	sseHeaders(c)
+	c.SSEvent(pubsub.EventSnapshot, pubsub.Event{Type: pubsub.EventSnapshot, Data: orders, At: time.Now()})
+	streamEvents(c, events, nil)
+}
+
+// AckOrder godoc
+// @Summary call AckOrder, return "Order acknowledged" by json.
+// @Description 주방 화면에서 주문 확인, 알림음 반복 중지. 주문이 변경되면 다시 확인 필요(피주문자가 수행)
+// @name AckOrder
+// @Accept  json
+// @Produce  json
+// @Param orderId path string true "orderId"
+// @Router /seller/ackOrder [put]
+// @Success 200 {object} Controller
+func (p *Controller) AckOrder(c *gin.Context) {
+	order, ok := p.kitchenOrder(c)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	if err := p.md.AckOrder(order.StoreID, order.ID, now); err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to acknowledge order", err.Error())
+		return
+	}
+	if order.AckedAt.IsZero() {
+		order.AckedAt = now
+	}
+
+	p.publishKitchen(order, pubsub.EventAcked, nil) //다른 주방 화면의 알림도 중지
+	c.JSON(200, gin.H{"result": "Order acknowledged"})
+	c.Next()
+}
+
+// BumpOrder godoc
+// @Summary call BumpOrder, return next order state by json.
+// @Description 주방 화면에서 주문을 다음 단계로 넘기기, 접수중 > 조리중 > 조리완료(포장 주문은 픽업대기). 확인하지 않은 주문은 확인 처리(피주문자가 수행)
+// @name BumpOrder
+// @Accept  json
+// @Produce  json
+// @Param orderId path string true "orderId"
+// @Router /seller/bumpOrder [put]
+// @Success 200 {object} Controller
+func (p *Controller) BumpOrder(c *gin.Context) {
+	order, ok := p.kitchenOrder(c)
+	if !ok {
+		return
+	}
+
+	next, err := order.NextKitchenState()
+	if err != nil {
+		p.RespError(c, nil, http.StatusConflict, "Can`t bump order", err.Error())
+		return
+	}
+	if err := p.md.UpdateStateByID(order.StoreID, order.ID, order.State, next); err != nil {
+		p.RespError(c, nil, http.StatusConflict, "Can`t bump order", err.Error())
+		return
+	}
+	now := time.Now()
+	if order.AckedAt.IsZero() {
+		if err := p.md.AckOrder(order.StoreID, order.ID, now); err != nil {
+			p.RespError(c, nil, http.StatusInternalServerError, "Failed to acknowledge order", err.Error())
+			return
+		}
+		order.AckedAt = now
+	}
+	order.State = next
+
+	p.publishOrder(order.ID, pubsub.EventState, next, nil)
+	p.publishKitchen(order, pubsub.EventState, nil)
+	c.JSON(200, gin.H{"msg": "Order bumped", "State": next})
+	c.Next()
+}
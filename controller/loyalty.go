@@ -0,0 +1,254 @@
+package controller
+
+// /loyalty.go : 적립금 및 스탬프 카드 적립, 주문시 사용, 원장 조회
+import (
+	"fmt"
+	"lecture/oos/model"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 적립금 만료 시간, 설정이 없으면 12개월
+func (p *Controller) pointsExpireAt(t time.Time) time.Time {
+	months := p.cf.Loyalty.ExpireMonths
+	if months <= 0 {
+		months = 12
+	}
+	return t.AddDate(0, months, 0)
+}
+
+// 주문에 적립금 및 스탬프 보상 메뉴 적용 후 원장에 사용 기록. 실패시 응답 status와 에러 반환
+func (p *Controller) applyLoyalty(store model.Store, order *model.OrderList, points int, cardID string, now, at time.Time) (int, error) {
+	var card *model.StampCard
+	reward := []model.OrderItem{}
+	if len(cardID) > 0 {
+		id, err := primitive.ObjectIDFromHex(cardID)
+		if err != nil {
+			return http.StatusUnprocessableEntity, model.ErrStampCardNotFound
+		}
+		sc, err := p.md.GetStampCard(store.ID, id)
+		if err == model.ErrStampCardNotFound {
+			return http.StatusNotFound, err
+		} else if err != nil {
+			return http.StatusInternalServerError, err
+		}
+		if !sc.Active(now) {
+			return http.StatusUnprocessableEntity, model.ErrStampCardInactive
+		}
+		item, status, err := p.orderItem(store.ID, sc.RewardMenu, nil, nil, now, at) //보상 메뉴 수량 차감
+		if err != nil {
+			return status, err
+		}
+		reward = append(reward, item)
+		card = &sc
+	}
+	if points > order.Price {
+		p.releaseItems(store.ID, reward, now)
+		return http.StatusUnprocessableEntity, fmt.Errorf("points can not exceed order amount %d", order.Price)
+	}
+
+	if order.ID.IsZero() { //원장에 남길 주문 번호
+		order.ID = primitive.NewObjectID()
+	}
+	if err := p.md.RedeemLoyalty(*order, points, card, now); err != nil {
+		p.releaseItems(store.ID, reward, now)
+		if err == model.ErrNotEnoughPoints || err == model.ErrNotEnoughStamps || err == model.ErrLoyaltyConflict {
+			return http.StatusConflict, err
+		}
+		return http.StatusInternalServerError, err
+	}
+
+	for _, item := range reward { //보상 메뉴는 전액 할인
+		order.Items = append(order.Items, item)
+		order.Subtotal += item.Price
+		order.Discount += item.Price
+		order.StampCardID = card.ID
+	}
+	order.PointsUsed = points
+	order.Price -= points
+	return http.StatusOK, nil
+}
+
+// 주문에 사용한 적립금 및 스탬프 복구, 주문 실패 및 취소시
+func (p *Controller) releaseLoyalty(order model.OrderList) {
+	if order.PointsUsed <= 0 && order.StampCardID.IsZero() {
+		return
+	}
+	if err := p.md.RestoreLoyalty(order, time.Now()); err != nil {
+		fmt.Println("Failed to restore loyalty", order.ID.Hex(), err)
+	}
+}
+
+// 수령 완료 주문 적립, 적립금은 결제 금액 기준이고 스탬프는 진행중인 스탬프 카드마다 적립
+func (p *Controller) earnLoyalty(order model.OrderList) {
+	if len(order.Pnum) <= 0 { //고객 번호가 없는 주문은 적립할 원장이 없음
+		return
+	}
+	now := time.Now()
+	cards, err := p.md.GetStampCards(order.StoreID)
+	if err != nil {
+		fmt.Println("Failed to get stamp cards", order.StoreID.Hex(), err)
+	}
+	points := order.Price * p.cf.Loyalty.Rate / 100
+	if err := p.md.EarnLoyalty(order, points, p.pointsExpireAt(now), cards, now); err != nil {
+		fmt.Println("Failed to earn loyalty", order.ID.Hex(), err)
+	}
+}
+
+// GetLoyalty godoc
+// @Summary call GetLoyalty, return points and stamps by json.
+// @Description 사용 가능한 적립금, 만료 시간별 적립금 및 스탬프 카드별 스탬프 수 조회. 만료된 적립금은 소멸 처리(주문자가 수행)
+// @name GetLoyalty
+// @Accept  json
+// @Produce  json
+// @Param pnum query string true "pnum"
+// @Router /customer/getLoyalty [get]
+// @Success 200 {object} Controller
+func (p *Controller) GetLoyalty(c *gin.Context) {
+	pnum := c.Query("pnum")
+	if len(pnum) <= 0 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	acc, err := p.md.GetLoyalty(pnum, time.Now())
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get points", err.Error())
+		return
+	}
+
+	c.JSON(200, gin.H{"Points": acc.Points, "Expiring": acc.Lots, "Stamps": acc.Stamps})
+	c.Next()
+}
+
+// GetLoyaltyLedger godoc
+// @Summary call GetLoyaltyLedger, return LedgerEntry list by json.
+// @Description 적립금 및 스탬프 적립, 사용, 복구, 소멸 기록 전체 조회(주문자가 수행)
+// @name GetLoyaltyLedger
+// @Accept  json
+// @Produce  json
+// @Param pnum query string true "pnum"
+// @Router /customer/getLoyaltyLedger [get]
+// @Success 200 {object} Controller
+func (p *Controller) GetLoyaltyLedger(c *gin.Context) {
+	pnum := c.Query("pnum")
+	if len(pnum) <= 0 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	entries, err := p.md.GetLedger(pnum)
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get ledger", err.Error())
+		return
+	}
+
+	c.JSON(200, gin.H{"Ledger": entries})
+	c.Next()
+}
+
+// CreateStampCard godoc
+// @Summary call CreateStampCard, return stamp card id by json.
+// @Description 스탬프 카드 등록 기능, menu 주문시(비어있으면 주문 1건당) 스탬프 적립, goal개를 모으면 rewardMenu 1개 무료(피주문자가 수행)
+// @name CreateStampCard
+// @Accept  json
+// @Produce  json
+// @Param name path string true "name"
+// @Param menu path string false "menu"
+// @Param goal path int true "goal (10번째 무료는 9)"
+// @Param rewardMenu path string true "rewardMenu"
+// @Param startAt path string true "startAt (YYYY-MM-DD HH:MM, 사업장 시간대)"
+// @Param endAt path string true "endAt (YYYY-MM-DD HH:MM, 사업장 시간대)"
+// @Router /seller/createStampCard [post]
+// @Success 200 {object} Controller
+func (p *Controller) CreateStampCard(c *gin.Context) {
+	store, err := p.md.GetStore(sellerStoreID(c))
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get store", err.Error())
+		return
+	}
+	goal, err := formInt(c, "goal", 1, -1)
+	if err != nil || goal == nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "goal must be 1 or more", nil)
+		return
+	}
+	card := model.StampCard{StoreID: store.ID, Name: c.PostForm("name"), Menu: c.PostForm("menu"), Goal: *goal, RewardMenu: c.PostForm("rewardMenu")}
+	if card.StartAt, err = time.ParseInLocation("2006-01-02 15:04", c.PostForm("startAt"), store.Location()); err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "startAt must be YYYY-MM-DD HH:MM", nil)
+		return
+	}
+	if card.EndAt, err = time.ParseInLocation("2006-01-02 15:04", c.PostForm("endAt"), store.Location()); err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "endAt must be YYYY-MM-DD HH:MM", nil)
+		return
+	}
+	if err := card.Validate(); err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+		return
+	}
+	for _, name := range []string{card.Menu, card.RewardMenu} {
+		if _, err := p.md.GetMenu(store.ID, "menu", name); len(name) > 0 && err != nil {
+			p.RespError(c, nil, http.StatusUnprocessableEntity, "Can`t find that menu", name)
+			return
+		}
+	}
+	card.CreatedAt = time.Now().Format("2006-01-02 15:04:05")
+
+	id, err := p.md.CreateStampCard(card)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to create stamp card", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Create stamp card Success", "Stamp Card ID": id.Hex()})
+	c.Next()
+}
+
+// RetireStampCard godoc
+// @Summary call RetireStampCard, return "Stamp card retired" by json.
+// @Description 스탬프 카드 사용 중지, 이후 적립 및 보상 사용 불가(피주문자가 수행)
+// @name RetireStampCard
+// @Accept  json
+// @Produce  json
+// @Param stampCardId path string true "stampCardId"
+// @Router /seller/retireStampCard [put]
+// @Success 200 {object} Controller
+func (p *Controller) RetireStampCard(c *gin.Context) {
+	cardID, err := primitive.ObjectIDFromHex(c.PostForm("stampCardId"))
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	if err := p.md.RetireStampCard(sellerStoreID(c), cardID); err == model.ErrStampCardNotFound {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find that stamp card", nil)
+		return
+	} else if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to retire stamp card", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Stamp card retired"})
+	c.Next()
+}
+
+// GetStampCards godoc
+// @Summary call GetStampCards, return StampCard list by json.
+// @Description 사업장 스탬프 카드 목록 조회(피주문자가 수행)
+// @name GetStampCards
+// @Accept  json
+// @Produce  json
+// @Router /seller/getStampCards [get]
+// @Success 200 {object} Controller
+func (p *Controller) GetStampCards(c *gin.Context) {
+	cards, err := p.md.GetStampCards(sellerStoreID(c))
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get stamp cards", err.Error())
+		return
+	}
+
+	c.JSON(200, gin.H{"Stamp Card List": cards})
+	c.Next()
+}
@@ -0,0 +1,115 @@
+package controller
+
+// /menu.go : 메뉴 상세 정보 입력 처리 및 상세 조회
+import (
+	"fmt"
+	"lecture/oos/model"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 요청에 포함된 메뉴 항목만 읽어 부분 수정 항목 생성
+func parseMenuPatch(c *gin.Context) (model.MenuPatch, error) {
+	var patch model.MenuPatch
+	var err error
+
+	if patch.Price, err = formInt(c, "price", 0, -1); err != nil {
+		return patch, err
+	}
+	if patch.Recommend, err = formInt(c, "recommend", 0, -1); err != nil {
+		return patch, err
+	}
+	if patch.Spiciness, err = formInt(c, "spiciness", 0, 5); err != nil {
+		return patch, err
+	}
+	if patch.Calories, err = formInt(c, "calories", 0, -1); err != nil {
+		return patch, err
+	}
+	if v, ok := c.GetPostForm("category"); ok {
+		patch.Category = &v
+	}
+	if v, ok := c.GetPostForm("description"); ok {
+		patch.Description = &v
+	}
+	if v, ok := c.GetPostForm("image"); ok {
+		patch.Image = &v
+	}
+
+	//원산지는 "재료:원산지" 형식, 여러개 입력 가능 ex. origin=소고기:호주산&origin=양상추:국내산
+	if values, ok := c.GetPostFormArray("origin"); ok {
+		origins := []model.Origin{}
+		for _, v := range splitValues(values) {
+			parts := strings.SplitN(v, ":", 2)
+			if len(parts) != 2 || len(strings.TrimSpace(parts[0])) <= 0 || len(strings.TrimSpace(parts[1])) <= 0 {
+				return patch, fmt.Errorf("origin must be ingredient:country, %s", v)
+			}
+			origins = append(origins, model.Origin{Ingredient: strings.TrimSpace(parts[0]), Country: strings.TrimSpace(parts[1])})
+		}
+		patch.Origins = &origins
+	}
+	//알레르기 성분은 여러개 또는 쉼표로 구분 ex. allergens=밀,대두
+	if values, ok := c.GetPostFormArray("allergens"); ok {
+		allergens := splitValues(values)
+		patch.Allergens = &allergens
+	}
+	return patch, nil
+}
+
+// 입력된 정수 항목 확인, max가 0보다 작으면 최대값 제한 없음
+func formInt(c *gin.Context, key string, min, max int) (*int, error) {
+	v, ok := c.GetPostForm(key)
+	if !ok {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < min || (max >= 0 && n > max) {
+		if max >= 0 {
+			return nil, fmt.Errorf("%s must be between %d and %d", key, min, max)
+		}
+		return nil, fmt.Errorf("%s must be %d or more", key, min)
+	}
+	return &n, nil
+}
+
+// 여러개 또는 쉼표로 입력된 값 나누기, 빈 값 제외
+func splitValues(values []string) []string {
+	result := []string{}
+	for _, value := range values {
+		for _, v := range strings.Split(value, ",") {
+			if v = strings.TrimSpace(v); len(v) > 0 {
+				result = append(result, v)
+			}
+		}
+	}
+	return result
+}
+
+// GetMenuDetail godoc
+// @Summary call GetMenuDetail, return BurgerKing menu by json.
+// @Description 메뉴 고유 번호로 원산지, 맵기, 알레르기 성분 등 상세 정보 조회(주문자가 수행)
+// @name GetMenuDetail
+// @Accept  json
+// @Produce  json
+// @Param menuId path string true "menuId"
+// @Router /customer/getMenuDetail/:menuId [get]
+// @Success 200 {object} Controller
+func (p *Controller) GetMenuDetail(c *gin.Context) {
+	menuID, err := primitive.ObjectIDFromHex(c.Param("menuId"))
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "invalid menuId", nil)
+		return
+	}
+
+	burger, err := p.md.GetMenuByID(menuID)
+	if err != nil {
+		p.RespError(c, nil, http.StatusNotFound, " Can`t find that menu", nil)
+		return
+	}
+
+	c.JSON(200, burger)
+	c.Next()
+}
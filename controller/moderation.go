@@ -0,0 +1,167 @@
+package controller
+
+// /moderation.go : 리뷰 답글, 신고, 금칙어 검사 및 관리자 숨김 처리
+import (
+	"lecture/oos/model"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 금칙어 포함 여부 확인, 포함시 검토 사유 반환
+func (p *Controller) filterReview(text string) string {
+	lower := strings.ToLower(text)
+	for _, word := range p.cf.Review.Blocklist {
+		if len(word) > 0 && strings.Contains(lower, strings.ToLower(word)) {
+			return "blocklist: " + word
+		}
+	}
+	return ""
+}
+
+// ReplyReview godoc
+// @Summary call ReplyReview, return "Reply registered" by json.
+// @Description 리뷰에 사업장 답글 작성 및 수정기능(피주문자가 수행)
+// @name ReplyReview
+// @Accept  json
+// @Produce  json
+// @Param reviewId path string true "reviewId"
+// @Param reply path string true "reply"
+// @Router /seller/replyReview [put]
+// @Success 200 {object} Controller
+func (p *Controller) ReplyReview(c *gin.Context) {
+	sReviewID := c.PostForm("reviewId")
+	reply := c.PostForm("reply")
+
+	if len(sReviewID) <= 0 || len(reply) <= 0 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	reviewID, err := primitive.ObjectIDFromHex(sReviewID)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "invalid reviewId", nil)
+		return
+	}
+
+	req := model.ReviewReply{Seller: c.GetString("user"), Reply: reply, CreatedAt: time.Now().Format("2006-01-02 15:04:05")}
+	if err := p.md.ReplyReview(sellerStoreID(c), reviewID, req); err == model.ErrReviewNotFound {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find that review", nil)
+		return
+	} else if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to reply review", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Reply registered"})
+	c.Next()
+}
+
+// ReportReview godoc
+// @Summary call ReportReview, return "Review reported" by json.
+// @Description 부적절한 리뷰 신고기능, 신고자는 로그인 계정(Authorization)으로 계정당 1회, 신고 누적시 관리자 검토 대상(주문자/피주문자가 수행)
+// @name ReportReview
+// @Accept  json
+// @Produce  json
+// @Param reviewId path string true "reviewId"
+// @Param reason path string true "reason"
+// @Router /customer/reportReview [post]
+// @Router /seller/reportReview [post]
+// @Success 200 {object} Controller
+func (p *Controller) ReportReview(c *gin.Context) {
+	sReviewID := c.PostForm("reviewId")
+	reporter := c.GetString("user") //신고자는 요청 내용이 아닌 로그인 계정으로 기록해 중복 신고 방지
+	reason := c.PostForm("reason")
+
+	role := "customer"
+	if strings.HasPrefix(c.FullPath(), "/seller") {
+		role = "seller"
+	}
+	if len(reporter) <= 0 {
+		p.RespError(c, nil, http.StatusUnauthorized, "Login required to report a review", nil)
+		return
+	}
+	if len(sReviewID) <= 0 || len(reason) <= 0 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	reviewID, err := primitive.ObjectIDFromHex(sReviewID)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "invalid reviewId", nil)
+		return
+	}
+
+	req := model.ReviewReport{Reporter: reporter, Role: role, Reason: reason, CreatedAt: time.Now().Format("2006-01-02 15:04:05")}
+	review, err := p.md.ReportReview(reviewID, req, p.cf.Review.ReportThreshold)
+	if err == model.ErrReviewNotFound { //리뷰가 없거나 이미 신고한 경우
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Can`t find that review or already reported", nil)
+		return
+	} else if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to report review", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Review reported", "Report Count": review.ReportCount})
+	c.Next()
+}
+
+// GetFlaggedReviews godoc
+// @Summary call GetFlaggedReviews, return MenuReview list by json.
+// @Description 금칙어 포함 또는 신고 누적으로 검토가 필요한 리뷰 조회(관리자가 수행)
+// @name GetFlaggedReviews
+// @Accept  json
+// @Produce  json
+// @Router /admin/getFlaggedReviews [get]
+// @Success 200 {object} Controller
+func (p *Controller) GetFlaggedReviews(c *gin.Context) {
+	reviews, err := p.md.GetFlaggedReviews()
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get review list", err.Error())
+		return
+	}
+
+	p.setImageURLs(reviews)
+	c.JSON(200, gin.H{"Review List": reviews})
+	c.Next()
+}
+
+// HideReview godoc
+// @Summary call HideReview, return "Review visibility changed" by json.
+// @Description 리뷰 숨김 및 숨김 해제, 숨긴 리뷰는 조회 및 평점에서 제외(관리자가 수행)
+// @name HideReview
+// @Accept  json
+// @Produce  json
+// @Param reviewId path string true "reviewId"
+// @Param hidden path string true "hidden (true/false)"
+// @Router /admin/hideReview [put]
+// @Success 200 {object} Controller
+func (p *Controller) HideReview(c *gin.Context) {
+	sReviewID := c.PostForm("reviewId")
+	hidden, err := strconv.ParseBool(c.DefaultPostForm("hidden", "true"))
+	if len(sReviewID) <= 0 || err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	reviewID, err := primitive.ObjectIDFromHex(sReviewID)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "invalid reviewId", nil)
+		return
+	}
+
+	if err := p.md.SetReviewHidden(reviewID, hidden); err == model.ErrReviewNotFound {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find that review", nil)
+		return
+	} else if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to hide review", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Review visibility changed", "Hidden": hidden})
+	c.Next()
+}
@@ -0,0 +1,138 @@
+package controller
+
+// /option.go : 메뉴 옵션 그룹 설정 및 주문시 옵션 검증, 가격 계산
+import (
+	"encoding/json"
+	"fmt"
+	"lecture/oos/model"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 선택 옵션은 "그룹:옵션" 형식, 여러개 입력 가능 ex. options=사이즈:라지&options=소스:바베큐
+func parseSelectedOptions(values []string) ([]model.SelectedOption, error) {
+	selected := []model.SelectedOption{}
+	for _, v := range splitValues(values) {
+		parts := strings.SplitN(v, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("option must be group:option, %s", v)
+		}
+		selected = append(selected, model.SelectedOption{Group: strings.TrimSpace(parts[0]), Name: strings.TrimSpace(parts[1])})
+	}
+	return selected, nil
+}
+
+// 주문 메뉴 생성, 옵션/세트 구성 검증 및 가격 계산 후 수량 차감. 실패시 응답 status와 에러 반환
+// t는 주문 시간, at은 메뉴를 받을 시간 (예약 주문시 예약 시간)
+func (p *Controller) orderItem(storeID primitive.ObjectID, menuName string, optionValues, comboValues []string, t, at time.Time) (model.OrderItem, int, error) {
+	burger, err := p.md.GetMenu(storeID, "menu", menuName)
+	if err != nil {
+		return model.OrderItem{}, http.StatusUnprocessableEntity, model.ErrMenuNotFound
+	}
+
+	selected, err := parseSelectedOptions(optionValues)
+	if err != nil {
+		return model.OrderItem{}, http.StatusUnprocessableEntity, err
+	}
+	item, err := burger.NewOrderItem(selected)
+	if err != nil {
+		return item, http.StatusUnprocessableEntity, err
+	}
+	if burger.IsCombo() { //세트 메뉴는 구성 메뉴로 전개
+		choices, err := parseComboChoices(comboValues)
+		if err != nil {
+			return item, http.StatusUnprocessableEntity, err
+		}
+		if err := burger.AddComponents(&item, choices); err != nil {
+			return item, http.StatusUnprocessableEntity, err
+		}
+	} else if len(comboValues) > 0 {
+		return item, http.StatusUnprocessableEntity, fmt.Errorf("%s is not a combo menu", menuName)
+	}
+
+	//세트 메뉴 자체와 구성 메뉴 각각의 품절, 주문 가능 시간 확인 및 수량 차감
+	reserved := []string{}
+	for _, name := range itemMenus(item) {
+		if status, err := p.reserveMenu(storeID, name, t, at); err != nil {
+			for _, r := range reserved {
+				p.releaseMenu(storeID, r, t)
+			}
+			return item, status, fmt.Errorf("%s : %w", name, err)
+		}
+		reserved = append(reserved, name)
+	}
+	return item, http.StatusOK, nil
+}
+
+// 주문 메뉴와 세트 구성 메뉴 이름 목록
+func itemMenus(item model.OrderItem) []string {
+	names := []string{item.Menu}
+	for _, comp := range item.Components {
+		names = append(names, comp.Menu)
+	}
+	return names
+}
+
+// 주문 메뉴들의 차감했던 수량 복구
+func (p *Controller) releaseItems(storeID primitive.ObjectID, items []model.OrderItem, t time.Time) {
+	for _, item := range items {
+		for _, name := range itemMenus(item) {
+			p.releaseMenu(storeID, name, t)
+		}
+	}
+}
+
+// 주문 전체의 차감했던 수량 복구, 주문 메뉴 정보가 없는 이전 주문은 주문한 메뉴 이름 기준
+func (p *Controller) releaseOrder(store model.Store, order model.OrderList) {
+	t := orderedAt(store, order)
+	for _, name := range order.MenuNames() {
+		p.releaseMenu(order.StoreID, name, t)
+	}
+}
+
+// SetOptionGroups godoc
+// @Summary call SetOptionGroups, return "Option change success" by json.
+// @Description 메뉴 옵션 그룹 설정 기능, optionGroups는 json 배열 ex. [{"Name":"사이즈","Min":1,"Max":1,"Options":[{"Name":"라지","PriceDelta":700}]}](피주문자가 수행)
+// @name SetOptionGroups
+// @Accept  json
+// @Produce  json
+// @Param menu path string true "menu"
+// @Param optionGroups path string true "optionGroups"
+// @Router /seller/setOptionGroups [put]
+// @Success 200 {object} Controller
+func (p *Controller) SetOptionGroups(c *gin.Context) {
+	menuName := c.PostForm("menu")
+	sGroups := c.PostForm("optionGroups")
+
+	if len(menuName) <= 0 || len(sGroups) <= 0 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	groups := []model.OptionGroup{}
+	if err := json.Unmarshal([]byte(sGroups), &groups); err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "optionGroups must be json array", err.Error())
+		return
+	}
+	if err := model.ValidateOptionGroups(groups); err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+		return
+	}
+
+	burger, err := p.md.GetMenu(sellerStoreID(c), "menu", menuName)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, " Can`t find that menu", nil)
+		return
+	}
+	if err := p.md.SetOptionGroups(burger.ID, groups); err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Fail,parameter not found", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"msg": "Option change success", "Option Groups": groups})
+	c.Next()
+}
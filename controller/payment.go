@@ -0,0 +1,193 @@
+package controller
+
+// /payment.go : 주문 결제 요청, 승인, 수령시 매입 및 취소시 환불 처리
+import (
+	"errors"
+	"fmt"
+	"lecture/oos/model"
+	"lecture/oos/payment"
+	"lecture/oos/pubsub"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 주문 결제 정보 생성, 카드 결제는 결제 요청 후 결제 대기 상태. 주문 번호가 없으면 결제 요청을 위해 미리 생성
+func (p *Controller) startPayment(order *model.OrderList, method string) (payment.Intent, error) {
+	if order.ID.IsZero() {
+		order.ID = primitive.NewObjectID()
+	}
+	order.Payment = &model.Payment{Method: method, Status: model.PaymentPending, Amount: order.Price, Gateway: p.pg.Name()}
+	if method == model.PayCash {
+		return payment.Intent{}, nil
+	}
+
+	intent, err := p.pg.CreateIntent(order.ID.Hex(), order.Price)
+	if err != nil {
+		return intent, err
+	}
+	order.Payment.IntentID = intent.ID
+	order.State = model.StatePendingPayment
+	return intent, nil
+}
+
+// 수령 완료된 주문 결제 매입, 현금 결제는 수령 완료로 기록. 매입 실패시 사유를 기록하고 승인 상태를 유지해 재시도 가능
+func (p *Controller) settlePayment(order model.OrderList) error {
+	pay := order.Payment
+	if pay == nil {
+		return nil
+	}
+	amount := order.Price
+	if pay.Method == model.PayCard {
+		amount = pay.Amount
+		if pay.Status != model.PaymentAuthorized {
+			return nil
+		}
+		if err := p.pg.Capture(pay.IntentID, pay.Amount); err != nil {
+			if serr := p.md.SetPaymentFailure(order.ID, err.Error()); serr != nil {
+				return errors.Join(err, serr)
+			}
+			return err
+		}
+	}
+	return p.md.CapturePayment(order.ID, amount, time.Now())
+}
+
+// 취소할 주문의 환불 요청, 결제된 금액이 없으면 nil
+func refundFor(order model.OrderList, t time.Time) *model.Refund {
+	if !order.PaidByCard() {
+		return nil
+	}
+	switch order.Payment.Status {
+	case model.PaymentAuthorized, model.PaymentCaptured:
+		return &model.Refund{Amount: order.Payment.Amount, Status: model.RefundPending, RequestedAt: t}
+	}
+	return nil
+}
+
+// 취소된 주문 결제 처리, 매입 전이면 승인 취소, 매입 후면 환불. 결제 대행사 처리 실패시 환불 실패로 기록해 재시도 가능
+func (p *Controller) refundPayment(order model.OrderList) error {
+	pay := order.Payment
+	if pay == nil || pay.Method != model.PayCard {
+		return nil
+	}
+
+	now := time.Now()
+	switch pay.Status {
+	case model.PaymentPending, model.PaymentFailed, model.PaymentAuthorized:
+		if err := p.pg.Void(pay.IntentID); err != nil {
+			if order.Refund != nil {
+				if serr := p.md.CompleteRefund(order.ID, model.RefundFailed, "", now); serr != nil {
+					return errors.Join(err, serr)
+				}
+			}
+			return err
+		}
+		if err := p.md.SetPaymentStatus(order.ID, model.PaymentVoided); err != nil {
+			return err
+		}
+		if order.Refund != nil { //승인 금액 홀드 해제
+			return p.md.CompleteRefund(order.ID, model.RefundCompleted, "", now)
+		}
+	case model.PaymentCaptured:
+		refundID, err := p.pg.Refund(pay.IntentID, order.Refund.Amount)
+		if err != nil {
+			if serr := p.md.CompleteRefund(order.ID, model.RefundFailed, "", now); serr != nil {
+				return errors.Join(err, serr)
+			}
+			return err
+		}
+		if err := p.md.SetPaymentStatus(order.ID, model.PaymentRefunded); err != nil {
+			return err
+		}
+		return p.md.CompleteRefund(order.ID, model.RefundCompleted, refundID, now)
+	}
+	return nil
+}
+
+// 결제 대기 시간이 지난 카드 결제 주문 자동 취소, 차감한 수량, 쿠폰, 적립금 복구 및 결제 요청 취소. 취소한 주문 수 반환
+func (p *Controller) ExpirePendingPayments(now time.Time) int {
+	timeout := time.Duration(p.cf.Payment.PendingTimeout) * time.Minute
+	if timeout <= 0 {
+		timeout = 15 * time.Minute
+	}
+	orders, err := p.md.GetExpiredPendingPayments(now.Add(-timeout))
+	if err != nil {
+		fmt.Println("Failed to get pending payments", err)
+		return 0
+	}
+
+	expired := 0
+	for _, order := range orders {
+		store, err := p.md.GetStore(order.StoreID)
+		if err != nil {
+			fmt.Println("Failed to get store", order.StoreID.Hex(), err)
+			continue
+		}
+		cancel := model.Cancellation{By: model.CancelBySystem, Reason: model.ReasonPaymentTimeout, At: now}
+		if _, err := p.cancelOrder(store, order, cancel); err != nil { //그 사이 승인 또는 취소된 주문은 제외
+			continue
+		}
+		expired++
+	}
+	return expired
+}
+
+// ConfirmPayment godoc
+// @Summary call ConfirmPayment, return order state by json.
+// @Description 카드 결제 승인, 결제 화면에서 받은 paymentToken으로 승인되면 주문 접수(예약 주문은 예약접수). 거절되면 다시 시도 가능(주문자가 수행)
+// @name ConfirmPayment
+// @Accept  json
+// @Produce  json
+// @Param orderId path string true "orderId"
+// @Param pnum path string true "pnum"
+// @Param paymentToken path string true "paymentToken"
+// @Router /customer/confirmPayment [put]
+// @Success 200 {object} Controller
+func (p *Controller) ConfirmPayment(c *gin.Context) {
+	pnum := c.PostForm("pnum")
+	token := c.PostForm("paymentToken")
+	orderID, err := primitive.ObjectIDFromHex(c.PostForm("orderId"))
+	if len(pnum) <= 0 || len(token) <= 0 || err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	order, err := p.md.GetOrder(orderID)
+	if err != nil || order.Pnum != pnum {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find your order", nil)
+		return
+	}
+	if order.State != model.StatePendingPayment || !order.PaidByCard() {
+		p.RespError(c, nil, http.StatusConflict, "Order is not waiting for payment", order.State)
+		return
+	}
+
+	if err := p.pg.Authorize(order.Payment.IntentID, token); errors.Is(err, payment.ErrDeclined) {
+		p.md.FailPayment(order.ID, err.Error())
+		p.RespError(c, nil, http.StatusPaymentRequired, "Payment declined", err.Error())
+		return
+	} else if err != nil {
+		p.RespError(c, nil, http.StatusBadGateway, "Failed to authorize payment", err.Error())
+		return
+	}
+
+	state := model.StateReceived
+	if len(order.ScheduledTime) > 0 {
+		state = model.StateScheduled
+	}
+	if err := p.md.AuthorizePayment(order.ID, state, time.Now()); err != nil {
+		p.pg.Void(order.Payment.IntentID) //주문이 그 사이 취소되었으면 승인 취소
+		p.RespError(c, nil, http.StatusConflict, "Can`t confirm payment", err.Error())
+		return
+	}
+	order.State = state
+	order.Payment.Status = model.PaymentAuthorized
+
+	p.publishOrder(order.ID, pubsub.EventState, state, nil)
+	p.publishKitchen(order, pubsub.EventNew, &pubsub.Alert{Sound: pubsub.SoundNewOrder, Repeat: true})
+	c.JSON(200, gin.H{"msg": "Payment authorized", "State": state})
+	c.Next()
+}
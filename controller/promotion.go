@@ -0,0 +1,202 @@
+package controller
+
+// /promotion.go : 쿠폰 및 프로모션 등록, 사용 중지, 주문시 쿠폰 적용
+import (
+	"fmt"
+	"lecture/oos/model"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// 요청의 프로모션 항목 읽기, 사용 기간은 사업장 시간대 기준
+func parsePromotion(c *gin.Context, store model.Store) (model.Promotion, error) {
+	pr := model.Promotion{
+		StoreID: store.ID,
+		Code:    c.PostForm("code"),
+		Name:    c.PostForm("name"),
+		Type:    c.PostForm("type"),
+		BuyMenu: c.PostForm("buyMenu"),
+		GetMenu: c.PostForm("getMenu"),
+	}
+	ints := map[string]*int{
+		"value": &pr.Value, "maxDiscount": &pr.MaxDiscount, "buyQty": &pr.BuyQty, "getQty": &pr.GetQty,
+		"minOrder": &pr.MinOrder, "usageLimit": &pr.UsageLimit, "perCustomerLimit": &pr.PerCustomerLimit,
+	}
+	for key, dst := range ints {
+		n, err := formInt(c, key, 0, -1)
+		if err != nil {
+			return pr, err
+		}
+		if n != nil {
+			*dst = *n
+		}
+	}
+
+	var err error
+	if pr.StartAt, err = time.ParseInLocation("2006-01-02 15:04", c.PostForm("startAt"), store.Location()); err != nil {
+		return pr, fmt.Errorf("startAt must be YYYY-MM-DD HH:MM")
+	}
+	if pr.EndAt, err = time.ParseInLocation("2006-01-02 15:04", c.PostForm("endAt"), store.Location()); err != nil {
+		return pr, fmt.Errorf("endAt must be YYYY-MM-DD HH:MM")
+	}
+	return pr, pr.Validate()
+}
+
+// 주문에 쿠폰 적용 및 사용 처리, 증정 메뉴는 수량 차감 후 주문에 추가하고 할인 금액으로 계산. 실패시 응답 status와 에러 반환
+func (p *Controller) applyCoupon(store model.Store, order *model.OrderList, code string, now, at time.Time) (int, error) {
+	promo, err := p.md.GetPromotionByCode(store.ID, code)
+	if err == model.ErrPromotionNotFound {
+		return http.StatusNotFound, err
+	} else if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if err := promo.Check(now, order.Subtotal); err != nil {
+		return http.StatusUnprocessableEntity, err
+	}
+
+	discount := promo.Discount(*order)
+	free := []model.OrderItem{}
+	for i := 0; i < promo.FreeQty(*order); i++ {
+		item, status, err := p.orderItem(store.ID, promo.GetMenu, nil, nil, now, at)
+		if err != nil {
+			p.releaseItems(store.ID, free, now)
+			return status, fmt.Errorf("%w, %s", model.ErrPromotionNotApplicable, err.Error())
+		}
+		free = append(free, item)
+		discount += item.Price
+	}
+	if discount <= 0 {
+		return http.StatusUnprocessableEntity, model.ErrPromotionNotApplicable
+	}
+
+	if order.ID.IsZero() { //사용 기록에 남길 주문 번호
+		order.ID = primitive.NewObjectID()
+	}
+	if err := p.md.RedeemPromotion(promo, order.Pnum, order.ID); err != nil {
+		p.releaseItems(store.ID, free, now)
+		if err == model.ErrPromotionCustomerRequired {
+			return http.StatusUnprocessableEntity, err
+		}
+		if err == model.ErrPromotionExhausted || err == model.ErrPromotionCustomerLimit {
+			return http.StatusConflict, err
+		}
+		return http.StatusInternalServerError, err
+	}
+
+	order.Items = append(order.Items, free...)
+	for _, item := range free {
+		order.Subtotal += item.Price
+	}
+	order.Coupon, order.PromotionID, order.Discount = promo.Code, promo.ID, discount
+	order.Price = order.Subtotal + order.DeliveryFee - discount
+	return http.StatusOK, nil
+}
+
+// 주문에 사용한 쿠폰 사용 횟수 복구, 주문 실패 및 취소시
+func (p *Controller) releaseCoupon(order model.OrderList) {
+	if order.PromotionID.IsZero() {
+		return
+	}
+	if err := p.md.ReleasePromotion(order.PromotionID, order.Pnum, order.ID); err != nil {
+		fmt.Println("Failed to release coupon", order.Coupon, err)
+	}
+}
+
+// CreatePromotion godoc
+// @Summary call CreatePromotion, return promotion id by json.
+// @Description 쿠폰 등록 기능, type은 percent(value %), fixed(value 원), freeDelivery, buyXGetY(buyMenu buyQty개 구매시 getMenu getQty개 증정)(피주문자가 수행)
+// @name CreatePromotion
+// @Accept  json
+// @Produce  json
+// @Param code path string true "code"
+// @Param name path string true "name"
+// @Param type path string true "type (percent/fixed/freeDelivery/buyXGetY)"
+// @Param value path int false "value (할인율 또는 할인 금액)"
+// @Param maxDiscount path int false "maxDiscount (비율 할인 최대 금액, 0 : 제한 없음)"
+// @Param buyMenu path string false "buyMenu"
+// @Param buyQty path int false "buyQty"
+// @Param getMenu path string false "getMenu"
+// @Param getQty path int false "getQty"
+// @Param minOrder path int false "minOrder"
+// @Param startAt path string true "startAt (YYYY-MM-DD HH:MM, 사업장 시간대)"
+// @Param endAt path string true "endAt (YYYY-MM-DD HH:MM, 사업장 시간대)"
+// @Param usageLimit path int false "usageLimit (전체 사용 횟수, 0 : 제한 없음)"
+// @Param perCustomerLimit path int false "perCustomerLimit (고객당 사용 횟수, 0 : 제한 없음)"
+// @Router /seller/createPromotion [post]
+// @Success 200 {object} Controller
+func (p *Controller) CreatePromotion(c *gin.Context) {
+	store, err := p.md.GetStore(sellerStoreID(c))
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get store", err.Error())
+		return
+	}
+	pr, err := parsePromotion(c, store)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+		return
+	}
+	pr.CreatedAt = time.Now().Format("2006-01-02 15:04:05")
+
+	id, err := p.md.CreatePromotion(pr)
+	if mongo.IsDuplicateKeyError(err) {
+		p.RespError(c, nil, http.StatusConflict, "Coupon code already exists", nil)
+		return
+	} else if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to create promotion", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Create promotion Success", "Promotion ID": id.Hex()})
+	c.Next()
+}
+
+// RetirePromotion godoc
+// @Summary call RetirePromotion, return "Promotion retired" by json.
+// @Description 쿠폰 사용 중지, 이미 사용된 주문의 할인은 유지(피주문자가 수행)
+// @name RetirePromotion
+// @Accept  json
+// @Produce  json
+// @Param promotionId path string true "promotionId"
+// @Router /seller/retirePromotion [put]
+// @Success 200 {object} Controller
+func (p *Controller) RetirePromotion(c *gin.Context) {
+	promotionID, err := primitive.ObjectIDFromHex(c.PostForm("promotionId"))
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	if err := p.md.RetirePromotion(sellerStoreID(c), promotionID); err == model.ErrPromotionNotFound {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find that promotion", nil)
+		return
+	} else if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to retire promotion", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Promotion retired"})
+	c.Next()
+}
+
+// GetPromotions godoc
+// @Summary call GetPromotions, return Promotion list by json.
+// @Description 사업장 쿠폰 목록 및 사용 횟수 조회(피주문자가 수행)
+// @name GetPromotions
+// @Accept  json
+// @Produce  json
+// @Router /seller/getPromotions [get]
+// @Success 200 {object} Controller
+func (p *Controller) GetPromotions(c *gin.Context) {
+	promotions, err := p.md.GetPromotions(sellerStoreID(c))
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get promotions", err.Error())
+		return
+	}
+
+	c.JSON(200, gin.H{"Promotion List": promotions})
+	c.Next()
+}
@@ -0,0 +1,200 @@
+package controller
+
+// /receipt.go : 주문 영수증 JSON, 감열 프린터 텍스트, PDF 출력
+import (
+	"fmt"
+	"lecture/oos/model"
+	"lecture/oos/receipt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var fulfilmentLabels = map[string]string{
+	model.FulfilmentDelivery: "배달",
+	model.FulfilmentPickup:   "포장",
+	model.FulfilmentDineIn:   "매장 식사",
+}
+
+var paymentLabels = map[string]string{
+	model.PayCard:           "카드",
+	model.PayCash:           "현금",
+	model.PaymentPending:    "결제대기",
+	model.PaymentAuthorized: "승인",
+	model.PaymentCaptured:   "결제완료",
+	model.PaymentVoided:     "승인취소",
+	model.PaymentRefunded:   "환불",
+	model.PaymentFailed:     "결제실패",
+}
+
+// 설정된 통화와 부가세율, 설정이 없으면 KRW 10%
+func (p *Controller) taxDefaults() (string, int) {
+	if len(p.cf.Tax.Currency) <= 0 {
+		return model.CurrencyKRW, 10
+	}
+	return p.cf.Tax.Currency, p.cf.Tax.VATRate
+}
+
+// 주문 영수증 항목 만들기
+func (p *Controller) buildReceipt(store model.Store, order model.OrderList) receipt.Receipt {
+	tax := order.Tax(p.taxDefaults())
+	r := receipt.Receipt{
+		StoreName:    store.Name,
+		StoreAddress: store.Address,
+		StorePhone:   store.Phone,
+		OrderID:      order.ID.Hex(),
+		OrderTime:    order.OrderTime,
+		Fulfilment:   fulfilmentLabels[order.FulfilmentType()],
+		State:        order.State,
+		Items:        []receipt.Item{},
+		Subtotal:     order.Subtotal,
+		Adjustments:  []receipt.Adjustment{},
+		Total:        tax.Total,
+		Currency:     tax.Currency,
+		Digits:       model.CurrencyDigits(tax.Currency),
+		VATRate:      tax.VATRate,
+		Supply:       tax.Supply,
+		VAT:          tax.VAT,
+		Notes:        []string{},
+		IssuedAt:     time.Now().In(store.Location()).Format("2006-01-02 15:04:05"),
+	}
+	if len(order.ScheduledTime) > 0 {
+		r.Fulfilment += " (예약 " + order.ScheduledTime + ")"
+	}
+
+	for _, item := range order.Items {
+		ri := receipt.Item{Name: item.Menu, Amount: item.Price}
+		for _, opt := range item.Options {
+			ri.Details = append(ri.Details, fmt.Sprintf("+ %s: %s", opt.Group, opt.Name))
+		}
+		for _, comp := range item.Components {
+			ri.Details = append(ri.Details, fmt.Sprintf("- %s: %s", comp.Slot, comp.Menu))
+		}
+		r.Items = append(r.Items, ri)
+	}
+	if len(order.Items) <= 0 { //메뉴 구성이 없는 이전 주문
+		r.Items = append(r.Items, receipt.Item{Name: order.Menu, Amount: order.Subtotal})
+	}
+
+	if order.DeliveryFee > 0 {
+		r.Adjustments = append(r.Adjustments, receipt.Adjustment{Label: "배달비", Amount: order.DeliveryFee})
+	}
+	if order.Discount > 0 {
+		label := "할인"
+		if len(order.Coupon) > 0 {
+			label += " (쿠폰 " + order.Coupon + ")"
+		}
+		r.Adjustments = append(r.Adjustments, receipt.Adjustment{Label: label, Amount: -order.Discount})
+	}
+	if order.PointsUsed > 0 {
+		r.Adjustments = append(r.Adjustments, receipt.Adjustment{Label: "적립금 사용", Amount: -order.PointsUsed})
+	}
+
+	if pay := order.Payment; pay != nil {
+		r.Payment = paymentLabels[pay.Method] + " (" + paymentLabels[pay.Status] + ")"
+	}
+	if order.Cancelled() && order.Cancellation != nil {
+		r.Notes = append(r.Notes, fmt.Sprintf("%s %s", order.State, order.Cancellation.At.In(store.Location()).Format("2006-01-02 15:04:05")))
+	}
+	if rf := order.Refund; rf != nil {
+		r.Notes = append(r.Notes, fmt.Sprintf("환불 %s %s (%s)", receipt.FormatAmount(rf.Amount, r.Digits), r.Currency, rf.Status))
+	}
+	return r
+}
+
+// 요청 형식으로 영수증 응답, format은 json(기본), text, pdf
+func (p *Controller) writeReceipt(c *gin.Context, store model.Store, order model.OrderList) {
+	width := receipt.Width
+	if sWidth := c.Query("width"); len(sWidth) > 0 {
+		n, err := strconv.Atoi(sWidth)
+		if err != nil || n < 32 || n > 64 {
+			p.RespError(c, nil, http.StatusUnprocessableEntity, "width must be between 32 and 64", nil)
+			return
+		}
+		width = n
+	}
+
+	r := p.buildReceipt(store, order)
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+		c.JSON(200, r)
+	case "text":
+		c.String(200, r.Text(width))
+	case "pdf":
+		c.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"receipt-%s.pdf\"", order.ID.Hex()))
+		c.Data(200, "application/pdf", r.PDF(width))
+	default:
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "format must be json, text or pdf", nil)
+		return
+	}
+	c.Next()
+}
+
+// GetReceipt godoc
+// @Summary call GetReceipt, return receipt by json, text or pdf.
+// @Description 주문 영수증 조회, 메뉴별 금액, 배달비, 할인, 부가세 포함 합계의 공급가액과 부가세. format=text는 감열 프린터용, pdf는 파일(주문자가 수행)
+// @name GetReceipt
+// @Accept  json
+// @Produce  json
+// @Param orderId path string true "orderId"
+// @Param pnum query string true "pnum"
+// @Param format query string false "format (json/text/pdf, 기본 json)"
+// @Param width query int false "width (text, pdf 한 줄 글자 수 32~64, 기본 42)"
+// @Router /customer/getReceipt/:orderId [get]
+// @Success 200 {object} Controller
+func (p *Controller) GetReceipt(c *gin.Context) {
+	pnum := c.Query("pnum")
+	orderID, err := primitive.ObjectIDFromHex(c.Param("orderId"))
+	if len(pnum) <= 0 || err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	order, err := p.md.GetOrder(orderID)
+	if err != nil || order.Pnum != pnum {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find your order", nil)
+		return
+	}
+	store, err := p.md.GetStore(order.StoreID)
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get store", err.Error())
+		return
+	}
+
+	p.writeReceipt(c, store, order)
+}
+
+// PrintReceipt godoc
+// @Summary call PrintReceipt, return receipt by json, text or pdf.
+// @Description 사업장 주문 영수증 출력, format=text는 감열 프린터용, pdf는 파일(피주문자가 수행)
+// @name PrintReceipt
+// @Accept  json
+// @Produce  json
+// @Param orderId path string true "orderId"
+// @Param format query string false "format (json/text/pdf, 기본 json)"
+// @Param width query int false "width (text, pdf 한 줄 글자 수 32~64, 기본 42)"
+// @Router /seller/printReceipt/:orderId [get]
+// @Success 200 {object} Controller
+func (p *Controller) PrintReceipt(c *gin.Context) {
+	orderID, err := primitive.ObjectIDFromHex(c.Param("orderId"))
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	store, err := p.md.GetStore(sellerStoreID(c))
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get store", err.Error())
+		return
+	}
+	order, err := p.md.GetOrder(orderID)
+	if err != nil || order.StoreID != store.ID {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find that order", nil)
+		return
+	}
+
+	p.writeReceipt(c, store, order)
+}
@@ -0,0 +1,491 @@
+package controller
+
+// /rider.go : 배달원 등록, 배차, 배달 진행 및 배달원 위치 조회
+import (
+	"fmt"
+	"lecture/oos/model"
+	"lecture/oos/pubsub"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 배달원 계정 확인, 등록된 배달원이면 배달원 번호를 "riderId"로 저장
+func (p *Controller) RiderAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		rider, err := p.md.GetRiderByAccount(c.GetString("user"))
+		if err == model.ErrRiderNotFound {
+			p.RespError(c, nil, http.StatusForbidden, "Your account is not a rider", nil)
+			return
+		} else if err != nil {
+			p.RespError(c, nil, http.StatusInternalServerError, "Failed to get rider", err.Error())
+			return
+		}
+		c.Set("riderId", rider.ID)
+		c.Next()
+	}
+}
+
+// 로그인한 배달원 번호
+func riderID(c *gin.Context) primitive.ObjectID {
+	return c.MustGet("riderId").(primitive.ObjectID)
+}
+
+// 사업장에서 가까운 순으로 배달원 정렬, 위치 정보가 없는 배달원은 마지막
+func nearestRiders(store model.Store, riders []model.Rider) []model.Rider {
+	dist := func(r model.Rider) float64 {
+		if r.LocationAt.IsZero() {
+			return math.MaxFloat64
+		}
+		return model.Distance(store.Lat, store.Lng, r.Lat, r.Lng)
+	}
+	sort.SliceStable(riders, func(i, j int) bool { return dist(riders[i]) < dist(riders[j]) })
+	return riders
+}
+
+// 이동 거리(m)의 예상 소요 시간, 설정이 없으면 20km/h
+func (p *Controller) travelTime(meters float64) time.Duration {
+	speed := p.cf.Rider.Speed
+	if speed <= 0 {
+		speed = 20
+	}
+	return time.Duration(meters / (speed * 1000) * float64(time.Hour))
+}
+
+// 배달원의 주문 조회, 배차된 배달원이 아니면 에러
+func (p *Controller) riderOrder(c *gin.Context) (model.OrderList, bool) {
+	orderID, err := primitive.ObjectIDFromHex(c.PostForm("orderId"))
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return model.OrderList{}, false
+	}
+	order, err := p.md.GetOrder(orderID)
+	if err != nil || order.Delivery == nil || order.Delivery.RiderID != riderID(c) {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find your delivery", nil)
+		return order, false
+	}
+	return order, true
+}
+
+// CreateRider godoc
+// @Summary call CreateRider, return rider id by json.
+// @Description 배달원 등록 기능, 계정당 배달원 하나(관리자가 수행)
+// @name CreateRider
+// @Accept  json
+// @Produce  json
+// @Param name path string true "name"
+// @Param phone path string true "phone"
+// @Param account path string true "account"
+// @Router /admin/createRider [post]
+// @Success 200 {object} Controller
+func (p *Controller) CreateRider(c *gin.Context) {
+	name := c.PostForm("name")
+	phone := c.PostForm("phone")
+	account := c.PostForm("account")
+	if len(name) <= 0 || len(phone) <= 0 || len(account) <= 0 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	rider := model.Rider{Name: name, Phone: phone, Account: account, Status: model.RiderOffline, RegisteredAt: time.Now().Format("2006-01-02 15:04:05")}
+	id, err := p.md.CreateRider(rider)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to register rider", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Register rider Success", "Rider ID": id.Hex()})
+	c.Next()
+}
+
+// GetAvailableRiders godoc
+// @Summary call GetAvailableRiders, return Rider list by json.
+// @Description 배차 대기중인 배달원 조회, 사업장에서 가까운 순(피주문자가 수행)
+// @name GetAvailableRiders
+// @Accept  json
+// @Produce  json
+// @Router /seller/getAvailableRiders [get]
+// @Success 200 {object} Controller
+func (p *Controller) GetAvailableRiders(c *gin.Context) {
+	store, err := p.md.GetStore(sellerStoreID(c))
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get store", err.Error())
+		return
+	}
+	riders, err := p.md.GetAvailableRiders()
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get riders", err.Error())
+		return
+	}
+
+	c.JSON(200, gin.H{"Rider List": nearestRiders(store, riders)})
+	c.Next()
+}
+
+// AssignRider godoc
+// @Summary call AssignRider, return assigned Rider by json.
+// @Description 배달 주문에 배달원 배차, riderId가 없으면 사업장에서 가장 가까운 배차 대기 배달원(피주문자가 수행)
+// @name AssignRider
+// @Accept  json
+// @Produce  json
+// @Param orderId path string true "orderId"
+// @Param riderId path string false "riderId"
+// @Router /seller/assignRider [put]
+// @Success 200 {object} Controller
+func (p *Controller) AssignRider(c *gin.Context) {
+	orderID, err := primitive.ObjectIDFromHex(c.PostForm("orderId"))
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	storeID := sellerStoreID(c)
+	order, err := p.md.GetOrder(orderID)
+	if err != nil || order.StoreID != storeID {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find that order", nil)
+		return
+	}
+	if order.FulfilmentType() != model.FulfilmentDelivery {
+		p.RespError(c, nil, http.StatusConflict, "Only delivery orders need a rider", nil)
+		return
+	}
+	if order.Delivery != nil {
+		p.RespError(c, nil, http.StatusConflict, "Rider already assigned", order.Delivery.RiderName)
+		return
+	}
+	if order.CheckStateChange(model.StateDelivering) != nil { //배달 시작 전 주문만
+		p.RespError(c, nil, http.StatusConflict, "Can`t assign rider in state "+order.State, nil)
+		return
+	}
+
+	now := time.Now()
+	var rider model.Rider
+	if sRiderID := c.PostForm("riderId"); len(sRiderID) > 0 { //직접 배차
+		id, idErr := primitive.ObjectIDFromHex(sRiderID)
+		if idErr != nil {
+			p.RespError(c, nil, http.StatusUnprocessableEntity, "invalid riderId", nil)
+			return
+		}
+		rider, err = p.md.AssignRider(orderID, id, now)
+	} else { //가까운 배달원부터 배차 시도, 다른 주문에 먼저 배차되면 다음 배달원
+		store, storeErr := p.md.GetStore(storeID)
+		if storeErr != nil {
+			p.RespError(c, nil, http.StatusInternalServerError, "Failed to get store", storeErr.Error())
+			return
+		}
+		riders, listErr := p.md.GetAvailableRiders()
+		if listErr != nil {
+			p.RespError(c, nil, http.StatusInternalServerError, "Failed to get riders", listErr.Error())
+			return
+		}
+		err = model.ErrNoRider
+		for _, r := range nearestRiders(store, riders) {
+			if rider, err = p.md.AssignRider(orderID, r.ID, now); err != model.ErrRiderUnavailable {
+				break
+			}
+			err = model.ErrNoRider
+		}
+	}
+	if err == model.ErrRiderUnavailable || err == model.ErrNoRider {
+		p.RespError(c, nil, http.StatusConflict, "No rider to assign", err.Error())
+		return
+	} else if err != nil {
+		p.RespError(c, nil, http.StatusConflict, "Failed to assign rider", err.Error())
+		return
+	}
+
+	p.publishOrder(orderID, pubsub.EventRider, order.State, gin.H{"Status": model.RiderAssigned, "Rider": rider.Name, "Phone": rider.Phone})
+	c.JSON(200, gin.H{"msg": "Rider assigned", "Rider ID": rider.ID.Hex(), "Rider": rider.Name, "Phone": rider.Phone})
+	c.Next()
+}
+
+// SetRiderStatus godoc
+// @Summary call SetRiderStatus, return rider status by json.
+// @Description 배달원 근무 시작 및 종료, 배달중에는 변경 불가(배달원이 수행)
+// @name SetRiderStatus
+// @Accept  json
+// @Produce  json
+// @Param status path string true "status (available/offline)"
+// @Router /rider/setStatus [put]
+// @Success 200 {object} Controller
+func (p *Controller) SetRiderStatus(c *gin.Context) {
+	status := c.PostForm("status")
+	if status != model.RiderAvailable && status != model.RiderOffline {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "status must be available or offline", nil)
+		return
+	}
+
+	if err := p.md.SetRiderStatus(riderID(c), status); err != nil {
+		p.RespError(c, nil, http.StatusConflict, "Can`t change status during delivery", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"msg": "Rider status change success", "Status": status})
+	c.Next()
+}
+
+// GetRiderOrder godoc
+// @Summary call GetRiderOrder, return assigned OrderList by json.
+// @Description 배차된 주문과 사업장 정보 조회(배달원이 수행)
+// @name GetRiderOrder
+// @Accept  json
+// @Produce  json
+// @Router /rider/getOrder [get]
+// @Success 200 {object} Controller
+func (p *Controller) GetRiderOrder(c *gin.Context) {
+	rider, err := p.md.GetRider(riderID(c))
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get rider", err.Error())
+		return
+	}
+	if rider.OrderID.IsZero() {
+		p.RespError(c, nil, http.StatusNotFound, "No order assigned", nil)
+		return
+	}
+	order, err := p.md.GetOrder(rider.OrderID)
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get order", err.Error())
+		return
+	}
+	store, err := p.md.GetStore(order.StoreID)
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get store", err.Error())
+		return
+	}
+
+	c.JSON(200, gin.H{"Status": rider.Status, "Order": order, "Store": store.Name, "Store Address": store.Address, "Store Phone": store.Phone})
+	c.Next()
+}
+
+// AcceptDelivery godoc
+// @Summary call AcceptDelivery, return "Delivery accepted" by json.
+// @Description 배차된 주문 수락(배달원이 수행)
+// @name AcceptDelivery
+// @Accept  json
+// @Produce  json
+// @Param orderId path string true "orderId"
+// @Router /rider/accept [put]
+// @Success 200 {object} Controller
+func (p *Controller) AcceptDelivery(c *gin.Context) {
+	order, ok := p.riderOrder(c)
+	if !ok {
+		return
+	}
+
+	if err := p.md.AcceptDelivery(riderID(c), order.ID, time.Now()); err != nil {
+		p.RespError(c, nil, http.StatusConflict, "Can`t accept delivery", err.Error())
+		return
+	}
+
+	p.publishOrder(order.ID, pubsub.EventRider, order.State, gin.H{"Status": model.RiderDelivering, "Rider": order.Delivery.RiderName})
+	c.JSON(200, gin.H{"result": "Delivery accepted"})
+	c.Next()
+}
+
+// RejectDelivery godoc
+// @Summary call RejectDelivery, return "Delivery rejected" by json.
+// @Description 배차된 주문 거절, 수락 전에만 가능하며 주문은 다시 배차 대상(배달원이 수행)
+// @name RejectDelivery
+// @Accept  json
+// @Produce  json
+// @Param orderId path string true "orderId"
+// @Router /rider/reject [put]
+// @Success 200 {object} Controller
+func (p *Controller) RejectDelivery(c *gin.Context) {
+	order, ok := p.riderOrder(c)
+	if !ok {
+		return
+	}
+
+	if err := p.md.RejectDelivery(riderID(c), order.ID); err != nil {
+		p.RespError(c, nil, http.StatusConflict, "Can`t reject delivery", err.Error())
+		return
+	}
+
+	p.publishOrder(order.ID, pubsub.EventRider, order.State, gin.H{"Status": "rejected"})
+	c.JSON(200, gin.H{"result": "Delivery rejected"})
+	c.Next()
+}
+
+// PickUpDelivery godoc
+// @Summary call PickUpDelivery, return order state by json.
+// @Description 사업장에서 메뉴 픽업, 주문 상태는 배달중으로 변경(배달원이 수행)
+// @name PickUpDelivery
+// @Accept  json
+// @Produce  json
+// @Param orderId path string true "orderId"
+// @Router /rider/pickUp [put]
+// @Success 200 {object} Controller
+func (p *Controller) PickUpDelivery(c *gin.Context) {
+	order, ok := p.riderOrder(c)
+	if !ok {
+		return
+	}
+	if order.Delivery.AcceptedAt.IsZero() {
+		p.RespError(c, nil, http.StatusConflict, "Accept the delivery first", nil)
+		return
+	}
+	if !order.Delivery.PickedUpAt.IsZero() {
+		p.RespError(c, nil, http.StatusConflict, "Already picked up", nil)
+		return
+	}
+
+	if order.State != model.StateDelivering { //사업장에서 먼저 배달중으로 변경했으면 상태는 그대로
+		if err := order.CheckStateChange(model.StateDelivering); err != nil {
+			p.RespError(c, nil, http.StatusConflict, "Can`t pick up order", err.Error())
+			return
+		}
+		if err := p.md.UpdateStateByID(order.StoreID, order.ID, order.State, model.StateDelivering); err != nil {
+			p.RespError(c, nil, http.StatusConflict, "Can`t pick up order", err.Error())
+			return
+		}
+	}
+	if err := p.md.PickUpDelivery(riderID(c), order.ID, time.Now()); err != nil {
+		p.RespError(c, nil, http.StatusConflict, "Can`t pick up order", err.Error())
+		return
+	}
+
+	p.publishOrder(order.ID, pubsub.EventState, model.StateDelivering, nil)
+	c.JSON(200, gin.H{"msg": "Picked up", "State": model.StateDelivering})
+	c.Next()
+}
+
+// CompleteDelivery godoc
+// @Summary call CompleteDelivery, return order state by json.
+// @Description 배달 완료, 주문 상태는 배달완료로 변경되고 배달원은 배차 대기(배달원이 수행)
+// @name CompleteDelivery
+// @Accept  json
+// @Produce  json
+// @Param orderId path string true "orderId"
+// @Router /rider/deliver [put]
+// @Success 200 {object} Controller
+func (p *Controller) CompleteDelivery(c *gin.Context) {
+	order, ok := p.riderOrder(c)
+	if !ok {
+		return
+	}
+	if order.Delivery.PickedUpAt.IsZero() {
+		p.RespError(c, nil, http.StatusConflict, "Pick up the order first", nil)
+		return
+	}
+
+	if err := order.CheckStateChange(model.StateDelivered); err != nil {
+		p.RespError(c, nil, http.StatusConflict, "Can`t complete delivery", err.Error())
+		return
+	}
+	if err := p.md.UpdateStateByID(order.StoreID, order.ID, order.State, model.StateDelivered); err != nil {
+		p.RespError(c, nil, http.StatusConflict, "Can`t complete delivery", err.Error())
+		return
+	}
+	if err := p.md.CompleteDelivery(riderID(c), order.ID, time.Now()); err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to complete delivery", err.Error())
+		return
+	}
+
+	p.publishOrder(order.ID, pubsub.EventState, model.StateDelivered, nil)
+	order.State = model.StateDelivered
+	if err := p.settlePayment(order); err != nil {
+		fmt.Println("Failed to settle payment", order.ID.Hex(), err)
+	}
+	p.earnLoyalty(order)
+	c.JSON(200, gin.H{"msg": "Delivered", "State": model.StateDelivered})
+	c.Next()
+}
+
+// UpdateRiderLocation godoc
+// @Summary call UpdateRiderLocation, return "Location updated" by json.
+// @Description 배달원 현재 위치 전송, 배달중인 주문이 있으면 주문에 위치 기록(배달원이 수행)
+// @name UpdateRiderLocation
+// @Accept  json
+// @Produce  json
+// @Param lat path number true "lat"
+// @Param lng path number true "lng"
+// @Router /rider/updateLocation [put]
+// @Success 200 {object} Controller
+func (p *Controller) UpdateRiderLocation(c *gin.Context) {
+	lat, errLat := formFloat(c, "lat")
+	lng, errLng := formFloat(c, "lng")
+	if errLat != nil || errLng != nil || lat == nil || lng == nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "lat, lng must be a number", nil)
+		return
+	}
+
+	rider, err := p.md.UpdateRiderLocation(riderID(c), *lat, *lng, time.Now())
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to update location", err.Error())
+		return
+	}
+	if !rider.OrderID.IsZero() { //배달중인 주문 구독자에게 위치 전달
+		p.publishOrder(rider.OrderID, pubsub.EventLocation, "", gin.H{"Lat": rider.Lat, "Lng": rider.Lng})
+	}
+
+	c.JSON(200, gin.H{"result": "Location updated"})
+	c.Next()
+}
+
+// TrackOrder godoc
+// @Summary call TrackOrder, return rider location and ETA by json.
+// @Description 배달 주문의 배달원 위치와 도착 예정 시간 조회, 픽업 전이면 사업장을 거쳐 오는 시간(주문자가 수행)
+// @name TrackOrder
+// @Accept  json
+// @Produce  json
+// @Param orderId path string true "orderId"
+// @Param pnum query string true "pnum"
+// @Router /customer/trackOrder/:orderId [get]
+// @Success 200 {object} Controller
+func (p *Controller) TrackOrder(c *gin.Context) {
+	pnum := c.Query("pnum")
+	orderID, err := primitive.ObjectIDFromHex(c.Param("orderId"))
+	if len(pnum) <= 0 || err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	order, err := p.md.GetOrder(orderID)
+	if err != nil || order.Pnum != pnum {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find your order", nil)
+		return
+	}
+	if order.FulfilmentType() != model.FulfilmentDelivery {
+		p.RespError(c, nil, http.StatusConflict, "Only delivery orders can be tracked", nil)
+		return
+	}
+	d := order.Delivery
+	if d == nil {
+		c.JSON(200, gin.H{"State": order.State, "Rider": nil, "msg": "Rider not assigned yet"})
+		c.Next()
+		return
+	}
+
+	resp := gin.H{"State": order.State, "Rider": d.RiderName, "Phone": d.RiderPhone}
+	if !d.LocationAt.IsZero() {
+		resp["Lat"], resp["Lng"] = d.Lat, d.Lng
+		resp["Location Time"] = d.LocationAt.Format("2006-01-02 15:04:05")
+	}
+	if !d.DeliveredAt.IsZero() {
+		resp["Delivered Time"] = d.DeliveredAt.Format("2006-01-02 15:04:05")
+	} else if addr := order.DeliveryAddress; !d.LocationAt.IsZero() && addr != nil && (addr.Lat != 0 || addr.Lng != 0) {
+		var meters float64
+		if d.PickedUpAt.IsZero() { //픽업 전이면 사업장을 거쳐 배달
+			store, err := p.md.GetStore(order.StoreID)
+			if err != nil {
+				p.RespError(c, nil, http.StatusInternalServerError, "Failed to get store", err.Error())
+				return
+			}
+			meters = model.Distance(d.Lat, d.Lng, store.Lat, store.Lng) + model.Distance(store.Lat, store.Lng, addr.Lat, addr.Lng)
+		} else {
+			meters = model.Distance(d.Lat, d.Lng, addr.Lat, addr.Lng)
+		}
+		eta := p.travelTime(meters)
+		resp["Distance"] = int(meters)
+		resp["ETA Minutes"] = int(math.Ceil(eta.Minutes()))
+		resp["ETA"] = time.Now().Add(eta).Format("2006-01-02 15:04:05")
+	}
+
+	c.JSON(200, resp)
+	c.Next()
+}
@@ -0,0 +1,400 @@
+package controller
+
+// /store.go : 사업장 등록, 조회, 수정 및 사업장 계정 관리, 요청별 사업장 확인
+import (
+	"encoding/json"
+	"fmt"
+	"lecture/oos/model"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 사업장 계정(Authorization)의 소속 사업장 확인, 이후 요청은 해당 사업장에서만 처리
+func (p *Controller) SellerStore() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		store, err := p.md.GetStoreByStaff(c.GetString("user"))
+		if err == model.ErrStoreNotFound {
+			p.RespError(c, nil, http.StatusForbidden, "Your account doesn`t belong to any store", nil)
+			return
+		} else if err != nil {
+			p.RespError(c, nil, http.StatusInternalServerError, "Failed to get store", err.Error())
+			return
+		}
+		c.Set("storeId", store.ID)
+		c.Next()
+	}
+}
+
+// 관리자 계정 확인, 설정의 admin.accounts에 있는 계정만 처리
+func (p *Controller) AdminAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := c.GetString("user")
+		for _, account := range p.cf.Admin.Accounts {
+			if len(user) > 0 && user == account {
+				c.Next()
+				return
+			}
+		}
+		p.RespError(c, nil, http.StatusForbidden, "Your account is not an admin", nil)
+	}
+}
+
+// 로그인한 사업장 계정의 사업장 번호
+func sellerStoreID(c *gin.Context) primitive.ObjectID {
+	return c.MustGet("storeId").(primitive.ObjectID)
+}
+
+// 주문자가 선택한 사업장 번호 확인, query 또는 form의 storeId
+func (p *Controller) customerStoreID(c *gin.Context) (primitive.ObjectID, error) {
+	store, err := p.customerStore(c)
+	return store.ID, err
+}
+
+// 주문자가 선택한 사업장 정보 조회
+func (p *Controller) customerStore(c *gin.Context) (model.Store, error) {
+	sStoreID := c.Query("storeId")
+	if len(sStoreID) <= 0 {
+		sStoreID = c.PostForm("storeId")
+	}
+	if len(sStoreID) <= 0 {
+		return model.Store{}, fmt.Errorf("storeId not found")
+	}
+	storeID, err := primitive.ObjectIDFromHex(sStoreID)
+	if err != nil {
+		return model.Store{}, fmt.Errorf("invalid storeId")
+	}
+	store, err := p.md.GetStore(storeID)
+	if err != nil {
+		return model.Store{}, fmt.Errorf("Can`t find that store")
+	}
+	return store, nil
+}
+
+// 요청에 포함된 사업장 항목만 읽어 부분 수정 항목 생성
+func parseStorePatch(c *gin.Context) (model.StorePatch, error) {
+	var patch model.StorePatch
+
+	if v, ok := c.GetPostForm("name"); ok {
+		patch.Name = &v
+	}
+	if v, ok := c.GetPostForm("address"); ok {
+		patch.Address = &v
+	}
+	if v, ok := c.GetPostForm("phone"); ok {
+		patch.Phone = &v
+	}
+	var err error
+	if patch.Lat, err = formFloat(c, "lat"); err != nil {
+		return patch, err
+	}
+	if patch.Lng, err = formFloat(c, "lng"); err != nil {
+		return patch, err
+	}
+	if patch.DeliveryRadius, err = formInt(c, "deliveryRadius", 0, -1); err != nil {
+		return patch, err
+	}
+	if patch.LeadTime, err = formInt(c, "leadTime", 0, -1); err != nil {
+		return patch, err
+	}
+	if patch.PrepTime, err = formInt(c, "prepTime", 0, -1); err != nil {
+		return patch, err
+	}
+	if v, ok := c.GetPostForm("timeZone"); ok {
+		if err := model.ValidateTimeZone(v); err != nil {
+			return patch, err
+		}
+		patch.TimeZone = &v
+	}
+	//영업 시간은 json 배열 ex. [{"Weekday":1,"Open":"10:00","Close":"22:00"}]
+	if v, ok := c.GetPostForm("openingHours"); ok {
+		hours := []model.OpeningHour{}
+		if err := json.Unmarshal([]byte(v), &hours); err != nil {
+			return patch, fmt.Errorf("openingHours must be json array")
+		}
+		if err := model.ValidateOpeningHours(hours); err != nil {
+			return patch, err
+		}
+		patch.OpeningHours = &hours
+	}
+	//배달 구역은 json 배열 ex. [{"Name":"1km","Type":"radius","Radius":1000,"MinOrder":12000,"Fee":2000}]
+	if v, ok := c.GetPostForm("deliveryZones"); ok {
+		zones := []model.DeliveryZone{}
+		if err := json.Unmarshal([]byte(v), &zones); err != nil {
+			return patch, fmt.Errorf("deliveryZones must be json array")
+		}
+		if err := model.ValidateDeliveryZones(zones); err != nil {
+			return patch, err
+		}
+		patch.DeliveryZones = &zones
+	}
+	//휴무일은 json 배열 ex. [{"Date":"2026-12-25","Reason":"성탄절"}]
+	if v, ok := c.GetPostForm("holidays"); ok {
+		holidays := []model.Holiday{}
+		if err := json.Unmarshal([]byte(v), &holidays); err != nil {
+			return patch, fmt.Errorf("holidays must be json array")
+		}
+		if err := model.ValidateHolidays(holidays); err != nil {
+			return patch, err
+		}
+		patch.Holidays = &holidays
+	}
+	return patch, nil
+}
+
+// 입력된 실수 항목 확인
+func formFloat(c *gin.Context, key string) (*float64, error) {
+	v, ok := c.GetPostForm(key)
+	if !ok {
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a number", key)
+	}
+	return &f, nil
+}
+
+// GetStoreList godoc
+// @Summary call GetStoreList, return Store list by json.
+// @Description 주문 가능한 사업장 목록 조회(주문자가 수행)
+// @name GetStoreList
+// @Accept  json
+// @Produce  json
+// @Router /customer/getStoreList [get]
+// @Success 200 {object} Controller
+func (p *Controller) GetStoreList(c *gin.Context) {
+	stores, err := p.md.GetStoreList()
+	if err != nil {
+		p.RespError(c, nil, http.StatusInternalServerError, "Failed to get store list", err.Error())
+		return
+	}
+
+	c.JSON(200, gin.H{"Store List": stores})
+	c.Next()
+}
+
+// GetStore godoc
+// @Summary call GetStore, return Store by json.
+// @Description 사업장 정보 조회, 피주문자는 본인 사업장 조회(주문자/피주문자가 수행)
+// @name GetStore
+// @Accept  json
+// @Produce  json
+// @Param storeId path string true "storeId"
+// @Router /customer/getStore/:storeId [get]
+// @Router /seller/getStore [get]
+// @Success 200 {object} Controller
+func (p *Controller) GetStore(c *gin.Context) {
+	var storeID primitive.ObjectID
+	if id, ok := c.Get("storeId"); ok { //피주문자는 본인 사업장
+		storeID = id.(primitive.ObjectID)
+	} else {
+		id, err := primitive.ObjectIDFromHex(c.Param("storeId"))
+		if err != nil {
+			p.RespError(c, nil, http.StatusUnprocessableEntity, "invalid storeId", nil)
+			return
+		}
+		storeID = id
+	}
+
+	store, err := p.md.GetStore(storeID)
+	if err != nil {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find that store", nil)
+		return
+	}
+
+	c.JSON(200, store)
+	c.Next()
+}
+
+// UpdateStore godoc
+// @Summary call UpdateStore, return "Store change success" by json.
+// @Description 본인 사업장 정보 수정 기능, 입력한 항목만 변경(피주문자가 수행)
+// @name UpdateStore
+// @Accept  json
+// @Produce  json
+// @Param name path string false "name"
+// @Param address path string false "address"
+// @Param phone path string false "phone"
+// @Param lat path number false "lat"
+// @Param lng path number false "lng"
+// @Param timeZone path string false "timeZone (ex. Asia/Seoul)"
+// @Param openingHours path string false "openingHours (json 배열)"
+// @Param holidays path string false "holidays (json 배열)"
+// @Param deliveryRadius path int false "deliveryRadius (m, 0 : 제한 없음)"
+// @Param deliveryZones path string false "deliveryZones (json 배열, 정의한 순서대로 확인)"
+// @Param leadTime path int false "leadTime (예약 주문 최소 예약 시간 분, 0 : 기본값)"
+// @Param prepTime path int false "prepTime (예약 주문 준비 시간 분, 0 : 기본값)"
+// @Router /seller/updateStore [put]
+// @Success 200 {object} Controller
+func (p *Controller) UpdateStore(c *gin.Context) {
+	patch, err := parseStorePatch(c)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+		return
+	}
+
+	if err := p.md.UpdateStore(sellerStoreID(c), patch); err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Fail,parameter not found", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"msg": "Store change success"})
+	c.Next()
+}
+
+// CreateStore godoc
+// @Summary call CreateStore, return "Register store Success" by json.
+// @Description 신규 사업장 등록 기능, 사업장 계정은 addStaff로 추가(관리자가 수행)
+// @name CreateStore
+// @Accept  json
+// @Produce  json
+// @Param name path string true "name"
+// @Param address path string true "address"
+// @Param phone path string false "phone"
+// @Param lat path number false "lat"
+// @Param lng path number false "lng"
+// @Param timeZone path string false "timeZone (ex. Asia/Seoul)"
+// @Param openingHours path string false "openingHours (json 배열)"
+// @Param holidays path string false "holidays (json 배열)"
+// @Param deliveryRadius path int false "deliveryRadius (m, 0 : 제한 없음)"
+// @Param deliveryZones path string false "deliveryZones (json 배열, 정의한 순서대로 확인)"
+// @Param leadTime path int false "leadTime (예약 주문 최소 예약 시간 분, 0 : 기본값)"
+// @Param prepTime path int false "prepTime (예약 주문 준비 시간 분, 0 : 기본값)"
+// @Router /admin/createStore [post]
+// @Success 200 {object} Controller
+func (p *Controller) CreateStore(c *gin.Context) {
+	name := c.PostForm("name")
+	address := c.PostForm("address")
+
+	if len(name) <= 0 || len(address) <= 0 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+
+	patch, err := parseStorePatch(c)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+		return
+	}
+
+	req := model.Store{TimeZone: model.DefaultTimeZone, OpeningHours: []model.OpeningHour{}, Holidays: []model.Holiday{}, DeliveryZones: []model.DeliveryZone{}, Staff: []string{}, CreatedAt: time.Now().Format("2006-01-02 15:04:05")}
+	patch.Apply(&req)
+
+	storeID, err := p.md.CreateStore(req)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to register store", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Register store Success", "Store ID": storeID.Hex()})
+	c.Next()
+}
+
+// AddStaff godoc
+// @Summary call AddStaff, return "Staff added" by json.
+// @Description 사업장 계정 추가 기능, 계정은 한 사업장에만 소속(관리자가 수행)
+// @name AddStaff
+// @Accept  json
+// @Produce  json
+// @Param storeId path string true "storeId"
+// @Param account path string true "account"
+// @Router /admin/addStaff [put]
+// @Success 200 {object} Controller
+func (p *Controller) AddStaff(c *gin.Context) {
+	sStoreID := c.PostForm("storeId")
+	account := c.PostForm("account")
+
+	if len(sStoreID) <= 0 || len(account) <= 0 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+	storeID, err := primitive.ObjectIDFromHex(sStoreID)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "invalid storeId", nil)
+		return
+	}
+
+	if err := p.md.AddStaff(storeID, account); err == model.ErrStaffExists {
+		p.RespError(c, nil, http.StatusConflict, "That account already belongs to another store", nil)
+		return
+	} else if err == model.ErrStoreNotFound {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find that store", nil)
+		return
+	} else if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to add staff", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Staff added"})
+	c.Next()
+}
+
+// RemoveStaff godoc
+// @Summary call RemoveStaff, return "Staff removed" by json.
+// @Description 사업장 계정 삭제 기능(관리자가 수행)
+// @name RemoveStaff
+// @Accept  json
+// @Produce  json
+// @Param storeId query string true "storeId"
+// @Param account query string true "account"
+// @Router /admin/removeStaff [delete]
+// @Success 200 {object} Controller
+func (p *Controller) RemoveStaff(c *gin.Context) {
+	sStoreID := c.Query("storeId")
+	account := c.Query("account")
+
+	if len(sStoreID) <= 0 || len(account) <= 0 {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "parameter not found", nil)
+		return
+	}
+	storeID, err := primitive.ObjectIDFromHex(sStoreID)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "invalid storeId", nil)
+		return
+	}
+
+	if err := p.md.RemoveStaff(storeID, account); err == model.ErrStoreNotFound {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find that store", nil)
+		return
+	} else if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to remove staff", nil)
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Staff removed"})
+	c.Next()
+}
+
+// AssignLegacyData godoc
+// @Summary call AssignLegacyData, return assigned count by json.
+// @Description 사업장 구분 이전의 메뉴, 주문, 리뷰를 사업장으로 지정하는 기능(관리자가 수행)
+// @name AssignLegacyData
+// @Accept  json
+// @Produce  json
+// @Param storeId path string true "storeId"
+// @Router /admin/assignLegacyData [put]
+// @Success 200 {object} Controller
+func (p *Controller) AssignLegacyData(c *gin.Context) {
+	storeID, err := primitive.ObjectIDFromHex(c.PostForm("storeId"))
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "invalid storeId", nil)
+		return
+	}
+	if _, err := p.md.GetStore(storeID); err != nil {
+		p.RespError(c, nil, http.StatusNotFound, "Can`t find that store", nil)
+		return
+	}
+
+	count, err := p.md.AssignLegacyData(storeID)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "Failed to assign data", err.Error())
+		return
+	}
+
+	c.JSON(200, gin.H{"result": "Legacy data assigned", "Assigned": count})
+	c.Next()
+}
@@ -0,0 +1,83 @@
+package controller
+
+// /zone.go : 배달 구역 확인 및 배달비 포함 주문 금액 계산
+import (
+	"fmt"
+	"lecture/oos/model"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 주문 금액 계산 (메뉴 금액 + 배달비), 배달 주문은 배달 구역과 최소 주문 금액 확인. 실패시 응답 status와 에러 반환
+func priceOrder(store model.Store, order *model.OrderList) (int, error) {
+	order.Subtotal = 0
+	for _, item := range order.Items {
+		order.Subtotal += item.Price
+	}
+	order.DeliveryZone, order.DeliveryFee = "", 0
+
+	if order.FulfilmentType() == model.FulfilmentDelivery {
+		zone, err := store.FindZone(order.DeliveryAddress)
+		if err == model.ErrNoCoordinates {
+			return http.StatusUnprocessableEntity, fmt.Errorf("%w, choose saved address or send lat, lng", err)
+		} else if err != nil {
+			return http.StatusUnprocessableEntity, err
+		}
+		if order.Subtotal < zone.MinOrder {
+			return http.StatusUnprocessableEntity, fmt.Errorf("minimum order amount for %s is %d", zone.Name, zone.MinOrder)
+		}
+		order.DeliveryZone, order.DeliveryFee = zone.Name, zone.Fee
+	}
+	order.Price = order.Subtotal + order.DeliveryFee
+	return http.StatusOK, nil
+}
+
+// 변경한 메뉴 금액이 주문시 적용된 배달 구역의 최소 주문 금액 이상인지 확인
+func checkMinOrder(store model.Store, order model.OrderList, subtotal int) error {
+	if order.FulfilmentType() != model.FulfilmentDelivery || len(order.DeliveryZone) <= 0 {
+		return nil
+	}
+	for _, z := range store.DeliveryZones {
+		if z.Name == order.DeliveryZone && subtotal < z.MinOrder {
+			return fmt.Errorf("minimum order amount for %s is %d", z.Name, z.MinOrder)
+		}
+	}
+	return nil
+}
+
+// CheckDeliveryZone godoc
+// @Summary call CheckDeliveryZone, return DeliveryZone by json.
+// @Description 좌표의 배달 가능 여부와 배달 구역별 최소 주문 금액, 배달비 조회(주문자가 수행)
+// @name CheckDeliveryZone
+// @Accept  json
+// @Produce  json
+// @Param storeId query string true "storeId"
+// @Param lat query number true "lat"
+// @Param lng query number true "lng"
+// @Router /customer/checkDeliveryZone [get]
+// @Success 200 {object} Controller
+func (p *Controller) CheckDeliveryZone(c *gin.Context) {
+	store, err := p.customerStore(c)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, err.Error(), nil)
+		return
+	}
+	lat, errLat := strconv.ParseFloat(c.Query("lat"), 64)
+	lng, errLng := strconv.ParseFloat(c.Query("lng"), 64)
+	if errLat != nil || errLng != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "lat, lng must be a number", nil)
+		return
+	}
+	addr := model.Address{Lat: lat, Lng: lng}
+
+	zone, err := store.FindZone(&addr)
+	if err != nil {
+		p.RespError(c, nil, http.StatusUnprocessableEntity, "We don`t deliver to that address", err.Error())
+		return
+	}
+
+	c.JSON(200, gin.H{"Zone": zone.Name, "Min Order": zone.MinOrder, "Delivery Fee": zone.Fee})
+	c.Next()
+}
@@ -16,30 +16,2484 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin/addStaff": {
+            "put": {
+                "description": "사업장 계정 추가 기능, 계정은 한 사업장에만 소속(관리자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call AddStaff, return \"Staff added\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "storeId",
+                        "name": "storeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "account",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/assignLegacyData": {
+            "put": {
+                "description": "사업장 구분 이전의 메뉴, 주문, 리뷰를 사업장으로 지정하는 기능(관리자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call AssignLegacyData, return assigned count by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "storeId",
+                        "name": "storeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/createRider": {
+            "post": {
+                "description": "배달원 등록 기능, 계정당 배달원 하나(관리자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call CreateRider, return rider id by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "phone",
+                        "name": "phone",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "account",
+                        "name": "account",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/createStore": {
+            "post": {
+                "description": "신규 사업장 등록 기능, 사업장 계정은 addStaff로 추가(관리자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call CreateStore, return \"Register store Success\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "phone",
+                        "name": "phone",
+                        "in": "path"
+                    },
+                    {
+                        "type": "number",
+                        "description": "lat",
+                        "name": "lat",
+                        "in": "path"
+                    },
+                    {
+                        "type": "number",
+                        "description": "lng",
+                        "name": "lng",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "timeZone (ex. Asia/Seoul)",
+                        "name": "timeZone",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "openingHours (json 배열)",
+                        "name": "openingHours",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "holidays (json 배열)",
+                        "name": "holidays",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "deliveryRadius (m, 0 : 제한 없음)",
+                        "name": "deliveryRadius",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "deliveryZones (json 배열, 정의한 순서대로 확인)",
+                        "name": "deliveryZones",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "leadTime (예약 주문 최소 예약 시간 분, 0 : 기본값)",
+                        "name": "leadTime",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "prepTime (예약 주문 준비 시간 분, 0 : 기본값)",
+                        "name": "prepTime",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/getFlaggedReviews": {
+            "get": {
+                "description": "금칙어 포함 또는 신고 누적으로 검토가 필요한 리뷰 조회(관리자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetFlaggedReviews, return MenuReview list by json.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/hideReview": {
+            "put": {
+                "description": "리뷰 숨김 및 숨김 해제, 숨긴 리뷰는 조회 및 평점에서 제외(관리자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call HideReview, return \"Review visibility changed\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "reviewId",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "hidden (true/false)",
+                        "name": "hidden",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/purgeMenu/:menu": {
+            "delete": {
+                "description": "삭제된 메뉴 영구 삭제 기능(관리자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call PurgeMenu, return \"Purge menu success\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "menu",
+                        "name": "menu",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "storeId",
+                        "name": "storeId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/removeStaff": {
+            "delete": {
+                "description": "사업장 계정 삭제 기능(관리자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call RemoveStaff, return \"Staff removed\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "storeId",
+                        "name": "storeId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "account",
+                        "name": "account",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/addAddress": {
+            "post": {
+                "description": "배달 주소 저장, 처음 저장한 주소는 기본 주소(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call AddAddress, return \"Address added\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pnum",
+                        "name": "pnum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "label (ex. 집, 회사)",
+                        "name": "label",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "street",
+                        "name": "street",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "detail",
+                        "name": "detail",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "postalCode",
+                        "name": "postalCode",
+                        "in": "path"
+                    },
+                    {
+                        "type": "number",
+                        "description": "lat",
+                        "name": "lat",
+                        "in": "path"
+                    },
+                    {
+                        "type": "number",
+                        "description": "lng",
+                        "name": "lng",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "notes (배달 요청 사항)",
+                        "name": "notes",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "default (true/false)",
+                        "name": "default",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
         "/customer/addMenu": {
             "put": {
-                "description": "메뉴추가 기능과 배달중이면 신규주문 접수 기능(주문자가 수행)",
+                "description": "메뉴추가 기능과 배달중/픽업대기 등 조리가 끝났으면 신규주문 접수 기능, 신규주문은 현금 결제(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call AddMenu, return success,fail by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "menu",
+                        "name": "menu",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "changeMenu",
+                        "name": "changeMenu",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "options (그룹:옵션)",
+                        "name": "options",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "combo (슬롯:메뉴, 세트 메뉴만)",
+                        "name": "combo",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "storeId",
+                        "name": "storeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/cancelOrder": {
+            "put": {
+                "description": "주문 취소 기능, 결제대기, 접수중 및 예약접수는 항상, 조리중은 주문 후 취소 가능 시간 이내만 가능. 차감된 수량 복구 및 카드 결제 환불(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call CancelOrder, return cancelled state and refund by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "orderId",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pnum",
+                        "name": "pnum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "note (취소 사유)",
+                        "name": "note",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/changeMenu": {
+            "put": {
+                "description": "메뉴변경 기능과 조리중/배달중/픽업대기면 변경 미수행 기능, 예약 주문은 변경 가능, 배달 구역 최소 주문 금액 미만으로 변경 불가(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call ChangeMenu, return success,fail by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "menu",
+                        "name": "menu",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "changeMenu",
+                        "name": "changeMenu",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "options (그룹:옵션)",
+                        "name": "options",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "combo (슬롯:메뉴, 세트 메뉴만)",
+                        "name": "combo",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "storeId",
+                        "name": "storeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/checkDeliveryZone": {
+            "get": {
+                "description": "좌표의 배달 가능 여부와 배달 구역별 최소 주문 금액, 배달비 조회(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call CheckDeliveryZone, return DeliveryZone by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "storeId",
+                        "name": "storeId",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "lat",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "lng",
+                        "name": "lng",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/confirmPayment": {
+            "put": {
+                "description": "카드 결제 승인, 결제 화면에서 받은 paymentToken으로 승인되면 주문 접수(예약 주문은 예약접수). 거절되면 다시 시도 가능(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call ConfirmPayment, return order state by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "orderId",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pnum",
+                        "name": "pnum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "paymentToken",
+                        "name": "paymentToken",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/deleteAddress/:addressId": {
+            "delete": {
+                "description": "저장된 배달 주소 삭제(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call DeleteAddress, return \"Address deleted\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "addressId",
+                        "name": "addressId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pnum",
+                        "name": "pnum",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/deleteReview/:reviewId": {
+            "delete": {
+                "description": "본인이 작성한 리뷰 삭제기능(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call DeleteReview, return \"Your review deleted\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "reviewId",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pnum",
+                        "name": "pnum",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/getAllOrderList": {
+            "get": {
+                "description": "주문 내역 조회, 주문 메뉴별 선택 옵션 및 가격 포함, 피주문자는 본인 사업장 주문만(주문자/피주문자 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetAllOrderList, return OrderList by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "storeId",
+                        "name": "storeId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pnum",
+                        "name": "pnum",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/getLoyalty": {
+            "get": {
+                "description": "사용 가능한 적립금, 만료 시간별 적립금 및 스탬프 카드별 스탬프 수 조회. 만료된 적립금은 소멸 처리(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetLoyalty, return points and stamps by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pnum",
+                        "name": "pnum",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/getLoyaltyLedger": {
+            "get": {
+                "description": "적립금 및 스탬프 적립, 사용, 복구, 소멸 기록 전체 조회(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetLoyaltyLedger, return LedgerEntry list by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pnum",
+                        "name": "pnum",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/getMenu/:sortOption": {
+            "get": {
+                "description": "메뉴 리스트의 정렬 기준을 정하고 조회기능(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetMenu, return sortOption, BurgerKing menu by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "sortOption",
+                        "name": "sortOption",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "storeId",
+                        "name": "storeId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/getMenuDetail/:menuId": {
+            "get": {
+                "description": "메뉴 고유 번호로 원산지, 맵기, 알레르기 성분 등 상세 정보 조회(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetMenuDetail, return BurgerKing menu by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "menuId",
+                        "name": "menuId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/getProfile": {
+            "get": {
+                "description": "고객 정보 및 저장된 배달 주소 조회(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetProfile, return Customer by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pnum",
+                        "name": "pnum",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/getReceipt/:orderId": {
+            "get": {
+                "description": "주문 영수증 조회, 메뉴별 금액, 배달비, 할인, 부가세 포함 합계의 공급가액과 부가세. format=text는 감열 프린터용, pdf는 파일(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetReceipt, return receipt by json, text or pdf.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "orderId",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pnum",
+                        "name": "pnum",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "format (json/text/pdf, 기본 json)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "width (text, pdf 한 줄 글자 수 32~64, 기본 42)",
+                        "name": "width",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/getReview/:menuName": {
+            "get": {
+                "description": "메뉴별 평점 및 리뷰 조회기능(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetReview, return MenuReview by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "menuName",
+                        "name": "menuName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "storeId",
+                        "name": "storeId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/getReviewList/:menuName": {
+            "get": {
+                "description": "메뉴별 전체 리뷰를 최신순으로 페이지 단위 조회, 평균/개수/평점별 분포 포함(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetReviewList, return MenuReview list and review stats by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "menuName",
+                        "name": "menuName",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "page (default 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "size (default 10, max 50)",
+                        "name": "size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "storeId",
+                        "name": "storeId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/getStore/:storeId": {
+            "get": {
+                "description": "사업장 정보 조회, 피주문자는 본인 사업장 조회(주문자/피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetStore, return Store by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "storeId",
+                        "name": "storeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/getStoreList": {
+            "get": {
+                "description": "주문 가능한 사업장 목록 조회(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetStoreList, return Store list by json.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/orderMenu": {
+            "post": {
+                "description": "메뉴 주문기능과 주문번호 받는 기능, 옵션은 그룹:옵션 형식, 영업 시간 외에는 예약 주문만 가능, 주소는 배달 주문만 필요, 카드 결제는 confirmPayment로 승인 후 접수(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call OrderMenu, return \"Order Success\", count by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "menu",
+                        "name": "menu",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "options (그룹:옵션)",
+                        "name": "options",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "combo (슬롯:메뉴, 세트 메뉴만)",
+                        "name": "combo",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "storeId",
+                        "name": "storeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pnum",
+                        "name": "pnum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "fulfilment (delivery, pickup, dineIn / default delivery)",
+                        "name": "fulfilment",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "addressId (저장된 배달 주소)",
+                        "name": "addressId",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "address (addressId가 없을 때 직접 입력, 둘다 없으면 기본 주소)",
+                        "name": "address",
+                        "in": "path"
+                    },
+                    {
+                        "type": "number",
+                        "description": "lat (직접 입력 주소 좌표, 배달 구역 확인)",
+                        "name": "lat",
+                        "in": "path"
+                    },
+                    {
+                        "type": "number",
+                        "description": "lng (직접 입력 주소 좌표, 배달 구역 확인)",
+                        "name": "lng",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "scheduledTime (YYYY-MM-DD HH:MM, 사업장 시간대, 최소 예약 시간 이후)",
+                        "name": "scheduledTime",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "paymentMethod (card/cash, 기본 card)",
+                        "name": "paymentMethod",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "coupon (쿠폰 코드, pnum 필요)",
+                        "name": "coupon",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "usePoints (사용할 적립금, pnum 필요)",
+                        "name": "usePoints",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "stampCard (보상 메뉴를 받을 스탬프 카드 id, pnum 필요)",
+                        "name": "stampCard",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Idempotency-Key (재시도시 같은 계정, pnum에서 같은 키와 같은 내용이면 처음 응답 반환)",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/reportReview": {
+            "post": {
+                "description": "부적절한 리뷰 신고기능, 신고자는 로그인 계정(Authorization)으로 계정당 1회, 신고 누적시 관리자 검토 대상(주문자/피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call ReportReview, return \"Review reported\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "reviewId",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "reason",
+                        "name": "reason",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/setDefaultAddress": {
+            "put": {
+                "description": "기본 배달 주소 변경, 주소 없이 주문하면 기본 주소로 배달(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call SetDefaultAddress, return \"Default address changed\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pnum",
+                        "name": "pnum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "addressId",
+                        "name": "addressId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/subscribeOrder/:orderId": {
+            "get": {
+                "description": "주문 상태 변경, 메뉴 변경, 배달원 배차 및 위치를 실시간 전달(SSE), 처음에 현재 상태를 보내고 주문이 완료, 취소 또는 거절되면 종료(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/event-stream"
+                ],
+                "summary": "call SubscribeOrder, return order events by text/event-stream.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "orderId",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pnum",
+                        "name": "pnum",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/trackOrder/:orderId": {
+            "get": {
+                "description": "배달 주문의 배달원 위치와 도착 예정 시간 조회, 픽업 전이면 사업장을 거쳐 오는 시간(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call TrackOrder, return rider location and ETA by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "orderId",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pnum",
+                        "name": "pnum",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/updateAddress": {
+            "put": {
+                "description": "저장된 배달 주소 수정, 이전 주문의 주소는 변경되지 않음(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call UpdateAddress, return \"Address updated\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pnum",
+                        "name": "pnum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "addressId",
+                        "name": "addressId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "label",
+                        "name": "label",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "street",
+                        "name": "street",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "detail",
+                        "name": "detail",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "postalCode",
+                        "name": "postalCode",
+                        "in": "path"
+                    },
+                    {
+                        "type": "number",
+                        "description": "lat",
+                        "name": "lat",
+                        "in": "path"
+                    },
+                    {
+                        "type": "number",
+                        "description": "lng",
+                        "name": "lng",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "notes",
+                        "name": "notes",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/updateProfile": {
+            "put": {
+                "description": "고객 정보 등록 및 수정(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call UpdateProfile, return \"Profile saved\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pnum",
+                        "name": "pnum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/updateReview": {
+            "put": {
+                "description": "본인이 작성한 리뷰의 평점 및 내용 수정기능(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call UpdateReview, return \"Your review updated\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "reviewId",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pnum",
+                        "name": "pnum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "grade",
+                        "name": "grade",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "review",
+                        "name": "review",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/customer/writeReview": {
+            "post": {
+                "description": "배달/픽업/서빙 완료된 본인 주문의 메뉴별 평점 작성기능, 주문 메뉴당 1회(주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call WriteReview, return \"Your review registered\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "orderId",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pnum",
+                        "name": "pnum",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "menu",
+                        "name": "menu",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "grade",
+                        "name": "grade",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "review",
+                        "name": "review",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "images (jpeg, png, gif)",
+                        "name": "images",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/images/:key": {
+            "get": {
+                "description": "리뷰 이미지 및 썸네일 제공",
+                "produces": [
+                    "image/png",
+                    "image/jpeg"
+                ],
+                "summary": "call GetImage, return image file.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/rider/accept": {
+            "put": {
+                "description": "배차된 주문 수락(배달원이 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call AcceptDelivery, return \"Delivery accepted\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "orderId",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/rider/deliver": {
+            "put": {
+                "description": "배달 완료, 주문 상태는 배달완료로 변경되고 배달원은 배차 대기(배달원이 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call CompleteDelivery, return order state by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "orderId",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/rider/getOrder": {
+            "get": {
+                "description": "배차된 주문과 사업장 정보 조회(배달원이 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetRiderOrder, return assigned OrderList by json.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/rider/pickUp": {
+            "put": {
+                "description": "사업장에서 메뉴 픽업, 주문 상태는 배달중으로 변경(배달원이 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call PickUpDelivery, return order state by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "orderId",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/rider/reject": {
+            "put": {
+                "description": "배차된 주문 거절, 수락 전에만 가능하며 주문은 다시 배차 대상(배달원이 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call RejectDelivery, return \"Delivery rejected\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "orderId",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/rider/setStatus": {
+            "put": {
+                "description": "배달원 근무 시작 및 종료, 배달중에는 변경 불가(배달원이 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call SetRiderStatus, return rider status by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "status (available/offline)",
+                        "name": "status",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/rider/updateLocation": {
+            "put": {
+                "description": "배달원 현재 위치 전송, 배달중인 주문이 있으면 주문에 위치 기록(배달원이 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call UpdateRiderLocation, return \"Location updated\" by json.",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "lat",
+                        "name": "lat",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "lng",
+                        "name": "lng",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/ackOrder": {
+            "put": {
+                "description": "주방 화면에서 주문 확인, 알림음 반복 중지. 주문이 변경되면 다시 확인 필요(피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call AckOrder, return \"Order acknowledged\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "orderId",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/assignRider": {
+            "put": {
+                "description": "배달 주문에 배달원 배차, riderId가 없으면 사업장에서 가장 가까운 배차 대기 배달원(피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call AssignRider, return assigned Rider by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "orderId",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "riderId",
+                        "name": "riderId",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/bumpOrder": {
+            "put": {
+                "description": "주방 화면에서 주문을 다음 단계로 넘기기, 접수중 \u003e 조리중 \u003e 조리완료(포장 주문은 픽업대기). 확인하지 않은 주문은 확인 처리(피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call BumpOrder, return next order state by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "orderId",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/createPromotion": {
+            "post": {
+                "description": "쿠폰 등록 기능, type은 percent(value %), fixed(value 원), freeDelivery, buyXGetY(buyMenu buyQty개 구매시 getMenu getQty개 증정)(피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call CreatePromotion, return promotion id by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "type (percent/fixed/freeDelivery/buyXGetY)",
+                        "name": "type",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "value (할인율 또는 할인 금액)",
+                        "name": "value",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maxDiscount (비율 할인 최대 금액, 0 : 제한 없음)",
+                        "name": "maxDiscount",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "buyMenu",
+                        "name": "buyMenu",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "buyQty",
+                        "name": "buyQty",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "getMenu",
+                        "name": "getMenu",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "getQty",
+                        "name": "getQty",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "minOrder",
+                        "name": "minOrder",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "startAt (YYYY-MM-DD HH:MM, 사업장 시간대)",
+                        "name": "startAt",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "endAt (YYYY-MM-DD HH:MM, 사업장 시간대)",
+                        "name": "endAt",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "usageLimit (전체 사용 횟수, 0 : 제한 없음)",
+                        "name": "usageLimit",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "perCustomerLimit (고객당 사용 횟수, 0 : 제한 없음)",
+                        "name": "perCustomerLimit",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/createStampCard": {
+            "post": {
+                "description": "스탬프 카드 등록 기능, menu 주문시(비어있으면 주문 1건당) 스탬프 적립, goal개를 모으면 rewardMenu 1개 무료(피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call CreateStampCard, return stamp card id by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "menu",
+                        "name": "menu",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "goal (10번째 무료는 9)",
+                        "name": "goal",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "rewardMenu",
+                        "name": "rewardMenu",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "startAt (YYYY-MM-DD HH:MM, 사업장 시간대)",
+                        "name": "startAt",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "endAt (YYYY-MM-DD HH:MM, 사업장 시간대)",
+                        "name": "endAt",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/delete/:menu": {
+            "delete": {
+                "description": "메뉴판 삭제 기능, 안보임 처리 후 복구 가능(피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call DeleteMenu, return \"Delete menu success\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "menu",
+                        "name": "menu",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/getAvailableRiders": {
+            "get": {
+                "description": "배차 대기중인 배달원 조회, 사업장에서 가까운 순(피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetAvailableRiders, return Rider list by json.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/getDeletedMenu": {
+            "get": {
+                "description": "삭제된 메뉴 리스트 조회(피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetDeletedMenu, return deleted BurgerKing menu by json.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/getOrderList": {
+            "get": {
+                "description": "주문 내역 조회, 주문 메뉴별 선택 옵션 및 가격 포함, 피주문자는 본인 사업장 주문만(주문자/피주문자 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetAllOrderList, return OrderList by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "storeId",
+                        "name": "storeId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pnum",
+                        "name": "pnum",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/getPromotions": {
+            "get": {
+                "description": "사업장 쿠폰 목록 및 사용 횟수 조회(피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetPromotions, return Promotion list by json.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/getScheduledOrders": {
+            "get": {
+                "description": "주방 전달 전 대기중인 예약 주문 조회, 전달 시간순(피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetScheduledOrders, return scheduled OrderList by json.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/getStampCards": {
+            "get": {
+                "description": "사업장 스탬프 카드 목록 조회(피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetStampCards, return StampCard list by json.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/getStore": {
+            "get": {
+                "description": "사업장 정보 조회, 피주문자는 본인 사업장 조회(주문자/피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call GetStore, return Store by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "storeId",
+                        "name": "storeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/kitchenStream": {
+            "get": {
+                "description": "주방 화면 실시간 주문 전달(SSE), 처음에 처리중인 주문 목록(snapshot)을 보내고 새 주문, 주문 변경, 취소, 상태 변경을 알림음 정보와 함께 전달(피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/event-stream"
+                ],
+                "summary": "call KitchenStream, return kitchen events by text/event-stream.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/pauseOrders": {
+            "put": {
+                "description": "주문 일시 중지 및 재개 기능, 중지중에는 신규 주문 및 예약 주문 불가(피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call PauseOrders, return store paused state by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "paused (true/false)",
+                        "name": "paused",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "reason",
+                        "name": "reason",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/printReceipt/:orderId": {
+            "get": {
+                "description": "사업장 주문 영수증 출력, format=text는 감열 프린터용, pdf는 파일(피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call PrintReceipt, return receipt by json, text or pdf.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "orderId",
+                        "name": "orderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "format (json/text/pdf, 기본 json)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "width (text, pdf 한 줄 글자 수 32~64, 기본 42)",
+                        "name": "width",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/register": {
+            "post": {
+                "description": "신규메뉴 등록기능, 카테고리/설명/원산지/맵기/알레르기/열량 선택 입력(피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call RegisterMenu, return \"\"Register menu Success\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "menu",
+                        "name": "menu",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "price",
+                        "name": "price",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "recommend",
+                        "name": "recommend",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "category",
+                        "name": "category",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "description",
+                        "name": "description",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "image",
+                        "name": "image",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "origin (재료:원산지)",
+                        "name": "origin",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "spiciness (0~5)",
+                        "name": "spiciness",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "allergens",
+                        "name": "allergens",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "calories",
+                        "name": "calories",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/rejectOrder": {
+            "put": {
+                "description": "주문 거절 기능, 조리 완료 전 주문만 가능. 사유 코드 soldOut, tooBusy, closing, outOfZone, other(note 필수). 차감된 수량 복구 및 카드 결제 환불(피주문자가 수행)",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "call AddMenu, return success,fail by json.",
+                "summary": "call RejectOrder, return rejected state and refund by json.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "menu",
-                        "name": "menu",
+                        "description": "orderId",
+                        "name": "orderId",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "changeMenu",
-                        "name": "changeMenu",
+                        "description": "reason",
+                        "name": "reason",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "note",
+                        "name": "note",
+                        "in": "path"
                     }
                 ],
                 "responses": {
@@ -52,28 +2506,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/customer/changeMenu": {
+        "/seller/replyReview": {
             "put": {
-                "description": "메뉴변경 기능과 조리중/배달중이면 변경 미수행 기능(주문자가 수행)",
+                "description": "리뷰에 사업장 답글 작성 및 수정기능(피주문자가 수행)",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "call ChangeMenu, return success,fail by json.",
+                "summary": "call ReplyReview, return \"Reply registered\" by json.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "menu",
-                        "name": "menu",
+                        "description": "reviewId",
+                        "name": "reviewId",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "changeMenu",
-                        "name": "changeMenu",
+                        "description": "reply",
+                        "name": "reply",
                         "in": "path",
                         "required": true
                     }
@@ -88,16 +2542,32 @@ const docTemplate = `{
                 }
             }
         },
-        "/customer/getAllOrderList": {
-            "get": {
-                "description": "전체 주문 내역 조회(주문자 수행)",
+        "/seller/reportReview": {
+            "post": {
+                "description": "부적절한 리뷰 신고기능, 신고자는 로그인 계정(Authorization)으로 계정당 1회, 신고 누적시 관리자 검토 대상(주문자/피주문자가 수행)",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "call GetAllOrderList, return OrderList by json.",
+                "summary": "call ReportReview, return \"Review reported\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "reviewId",
+                        "name": "reviewId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "reason",
+                        "name": "reason",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -108,21 +2578,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/customer/getMenu/:sortOption": {
-            "get": {
-                "description": "메뉴 리스트의 정렬 기준을 정하고 조회기능(주문자가 수행)",
+        "/seller/restore/:menu": {
+            "put": {
+                "description": "삭제된 메뉴 복구 기능(피주문자가 수행)",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "call GetMenu, return sortOption, BurgerKing menu by json.",
+                "summary": "call RestoreMenu, return \"Restore menu success\" by json.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "sortOption",
-                        "name": "sortOption",
+                        "description": "menu",
+                        "name": "menu",
                         "in": "path",
                         "required": true
                     }
@@ -137,21 +2607,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/customer/getReview/:menuName": {
-            "get": {
-                "description": "메뉴별 평점 및 리뷰 조회기능(주문자가 수행)",
+        "/seller/retirePromotion": {
+            "put": {
+                "description": "쿠폰 사용 중지, 이미 사용된 주문의 할인은 유지(피주문자가 수행)",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "call GetReview, return MenuReview by json.",
+                "summary": "call RetirePromotion, return \"Promotion retired\" by json.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "menuName",
-                        "name": "menuName",
+                        "description": "promotionId",
+                        "name": "promotionId",
                         "in": "path",
                         "required": true
                     }
@@ -166,16 +2636,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/customer/orderMenu": {
-            "post": {
-                "description": "메뉴 주문기능과 주문번호 받는 기능(주문자가 수행)",
+        "/seller/retireStampCard": {
+            "put": {
+                "description": "스탬프 카드 사용 중지, 이후 적립 및 보상 사용 불가(피주문자가 수행)",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "call OrderMenu, return \"Order Success\", count by json.",
+                "summary": "call RetireStampCard, return \"Stamp card retired\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "stampCardId",
+                        "name": "stampCardId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/setAvailability": {
+            "put": {
+                "description": "메뉴 일일 한정 수량 및 주문 가능 시간 설정 기능(피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call SetAvailability, return \"Availability change success\" by json.",
                 "parameters": [
                     {
                         "type": "string",
@@ -184,19 +2683,23 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "integer",
+                        "description": "dailyStock (0 : 제한 없음)",
+                        "name": "dailyStock",
+                        "in": "path"
+                    },
                     {
                         "type": "string",
-                        "description": "pnum",
-                        "name": "pnum",
-                        "in": "path",
-                        "required": true
+                        "description": "availableFrom (HH:MM)",
+                        "name": "availableFrom",
+                        "in": "path"
                     },
                     {
                         "type": "string",
-                        "description": "address",
-                        "name": "address",
-                        "in": "path",
-                        "required": true
+                        "description": "availableTo (HH:MM)",
+                        "name": "availableTo",
+                        "in": "path"
                     }
                 ],
                 "responses": {
@@ -209,16 +2712,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/customer/writeReview": {
-            "post": {
-                "description": "메뉴별 평점 작성기능(주문자가 수행)",
+        "/seller/setComboSlots": {
+            "put": {
+                "description": "세트 메뉴 구성 설정 기능, slots는 json 배열 ex. [{\"Name\":\"사이드\",\"Choices\":[{\"Menu\":\"감자튀김\",\"Upcharge\":0},{\"Menu\":\"치즈스틱\",\"Upcharge\":500}]}](피주문자가 수행)",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "call WriteReview, return \"Your review registered\" by json.",
+                "summary": "call SetComboSlots, return \"Combo change success\" by json.",
                 "parameters": [
                     {
                         "type": "string",
@@ -229,15 +2732,44 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "grade",
-                        "name": "grade",
+                        "description": "slots",
+                        "name": "slots",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
+        },
+        "/seller/setOptionGroups": {
+            "put": {
+                "description": "메뉴 옵션 그룹 설정 기능, optionGroups는 json 배열 ex. [{\"Name\":\"사이즈\",\"Min\":1,\"Max\":1,\"Options\":[{\"Name\":\"라지\",\"PriceDelta\":700}]}](피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call SetOptionGroups, return \"Option change success\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "menu",
+                        "name": "menu",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "review",
-                        "name": "review",
+                        "description": "optionGroups",
+                        "name": "optionGroups",
                         "in": "path",
                         "required": true
                     }
@@ -252,16 +2784,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/seller/delete/:menu": {
-            "delete": {
-                "description": "메뉴판 삭제 기능(피주문자가 수행)",
+        "/seller/soldOut/:menu": {
+            "put": {
+                "description": "메뉴 품절 설정 및 해제 기능, 호출할 때마다 전환(피주문자가 수행)",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "call DeleteMenu, return \"Delete menu success\" by json.",
+                "summary": "call SoldOut, return menu status by json.",
                 "parameters": [
                     {
                         "type": "string",
@@ -281,37 +2813,82 @@ const docTemplate = `{
                 }
             }
         },
-        "/seller/register": {
-            "post": {
-                "description": "신규메뉴 등록기능(피주문자가 수행)",
+        "/seller/updateMenu": {
+            "put": {
+                "description": "메뉴판 수정 기능, 입력한 항목만 변경(피주문자가 수행)",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "call RegisterMenu, return \"\"Register menu Success\" by json.",
+                "summary": "call UpdateMenu, return \"Menu change success\" by json.",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "menuId",
+                        "name": "menuId",
+                        "in": "path"
+                    },
                     {
                         "type": "string",
                         "description": "menu",
                         "name": "menu",
-                        "in": "path",
-                        "required": true
+                        "in": "path"
                     },
                     {
                         "type": "string",
                         "description": "price",
                         "name": "price",
-                        "in": "path",
-                        "required": true
+                        "in": "path"
                     },
                     {
                         "type": "string",
                         "description": "recommend",
                         "name": "recommend",
-                        "in": "path",
-                        "required": true
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "category",
+                        "name": "category",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "description",
+                        "name": "description",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "image",
+                        "name": "image",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "origin (재료:원산지)",
+                        "name": "origin",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "spiciness (0~5)",
+                        "name": "spiciness",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "allergens",
+                        "name": "allergens",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "calories",
+                        "name": "calories",
+                        "in": "path"
                     }
                 ],
                 "responses": {
@@ -322,11 +2899,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/seller/updateMenu": {
-            "put": {
-                "description": "메뉴판 수정 기능(피주문자가 수행)",
+            },
+            "patch": {
+                "description": "메뉴판 수정 기능, 입력한 항목만 변경(피주문자가 수행)",
                 "consumes": [
                     "application/json"
                 ],
@@ -335,26 +2910,71 @@ const docTemplate = `{
                 ],
                 "summary": "call UpdateMenu, return \"Menu change success\" by json.",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "menuId",
+                        "name": "menuId",
+                        "in": "path"
+                    },
                     {
                         "type": "string",
                         "description": "menu",
                         "name": "menu",
-                        "in": "path",
-                        "required": true
+                        "in": "path"
                     },
                     {
                         "type": "string",
                         "description": "price",
                         "name": "price",
-                        "in": "path",
-                        "required": true
+                        "in": "path"
                     },
                     {
                         "type": "string",
                         "description": "recommend",
                         "name": "recommend",
-                        "in": "path",
-                        "required": true
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "category",
+                        "name": "category",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "description",
+                        "name": "description",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "image",
+                        "name": "image",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "origin (재료:원산지)",
+                        "name": "origin",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "spiciness (0~5)",
+                        "name": "spiciness",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "allergens",
+                        "name": "allergens",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "calories",
+                        "name": "calories",
+                        "in": "path"
                     }
                 ],
                 "responses": {
@@ -369,7 +2989,7 @@ const docTemplate = `{
         },
         "/seller/updateOrderState": {
             "put": {
-                "description": "주문내역 조회 및 상태 변경(피주문자가 수행)",
+                "description": "주문내역 조회 및 상태 변경, 수령 방법별 순서대로만 변경 ex. 포장 주문은 조리중 -\u003e 픽업대기 -\u003e 픽업완료(피주문자가 수행)",
                 "consumes": [
                     "application/json"
                 ],
@@ -378,12 +2998,17 @@ const docTemplate = `{
                 ],
                 "summary": "call UpdateOrderState, return \"State change success\" by json.",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "orderId",
+                        "name": "orderId",
+                        "in": "path"
+                    },
                     {
                         "type": "string",
                         "description": "menu",
                         "name": "menu",
-                        "in": "path",
-                        "required": true
+                        "in": "path"
                     },
                     {
                         "type": "string",
@@ -402,6 +3027,100 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/seller/updateStore": {
+            "put": {
+                "description": "본인 사업장 정보 수정 기능, 입력한 항목만 변경(피주문자가 수행)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "call UpdateStore, return \"Store change success\" by json.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "address",
+                        "name": "address",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "phone",
+                        "name": "phone",
+                        "in": "path"
+                    },
+                    {
+                        "type": "number",
+                        "description": "lat",
+                        "name": "lat",
+                        "in": "path"
+                    },
+                    {
+                        "type": "number",
+                        "description": "lng",
+                        "name": "lng",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "timeZone (ex. Asia/Seoul)",
+                        "name": "timeZone",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "openingHours (json 배열)",
+                        "name": "openingHours",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "holidays (json 배열)",
+                        "name": "holidays",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "deliveryRadius (m, 0 : 제한 없음)",
+                        "name": "deliveryRadius",
+                        "in": "path"
+                    },
+                    {
+                        "type": "string",
+                        "description": "deliveryZones (json 배열, 정의한 순서대로 확인)",
+                        "name": "deliveryZones",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "leadTime (예약 주문 최소 예약 시간 분, 0 : 기본값)",
+                        "name": "leadTime",
+                        "in": "path"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "prepTime (예약 주문 준비 시간 분, 0 : 기본값)",
+                        "name": "prepTime",
+                        "in": "path"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/controller.Controller"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
@@ -1,6 +1,6 @@
 module lecture/oos
 
-go 1.19
+go 1.20
 
 require (
 	github.com/gin-gonic/gin v1.8.2
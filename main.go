@@ -9,13 +9,18 @@ import (
 	ctl "lecture/oos/controller"
 	"lecture/oos/logger"
 	"lecture/oos/model"
+	"lecture/oos/payment"
+	"lecture/oos/pubsub"
 	rt "lecture/oos/router"
+	"lecture/oos/scheduler"
+	"lecture/oos/storage"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" //사업장 시간대 정보, 서버에 zoneinfo가 없어도 사용
 
 	"golang.org/x/sync/errgroup"
 )
@@ -36,26 +41,38 @@ func main() {
 	}
 	logger.Debug("ready server....")
 
+	ps := pubsub.NewMemoryBroker(0) //실시간 주문 이벤트 브로커
 	if mod, err := model.NewModel(); err != nil {
 		fmt.Println(err)
-	} else if controller, err := ctl.NewCTL(mod); err != nil { //controller 모듈 설정
+	} else if bs, err := storage.NewLocalStore(cf.Storage.Path); err != nil { //이미지 저장소 설정
+		fmt.Println(err)
+	} else if pg, err := payment.NewGateway(cf.Payment.Gateway); err != nil { //결제 대행사 설정
+		fmt.Println(err)
+	} else if controller, err := ctl.NewCTL(mod, cf, bs, ps, pg); err != nil { //controller 모듈 설정
 		fmt.Println(err)
 	} else if rt, err := rt.NewRouter(controller); err != nil { //router 모듈 설정
 		fmt.Println(err)
+	} else if sch, err := scheduler.NewScheduler(mod, cf, ps, controller); err != nil { //예약 주문 전달, 결제 대기 주문 취소 모듈 설정
+		fmt.Println(err)
 	} else {
 		mapi := &http.Server{
 			Addr:           ":8080",
-			Handler:        rt.Idx(),
+			Handler:        ctl.WithResponseWriter(rt.Idx()), //SSE 구독은 핸들러에서 WriteTimeout 연장
 			ReadTimeout:    5 * time.Second,
 			WriteTimeout:   10 * time.Second,
 			MaxHeaderBytes: 1 << 20,
 		}
+		mapi.RegisterOnShutdown(ps.Close) //서버 종료시 구독 연결 종료
 
 		g.Go(func() error {
 			return mapi.ListenAndServe()
 		})
+		schCtx, stopScheduler := context.WithCancel(context.Background())
+		g.Go(func() error {
+			return sch.Run(schCtx)
+		})
 
-		stopSig := make(chan os.Signal) //chan 선언
+		stopSig := make(chan os.Signal, 1) //chan 선언
 		// 해당 chan 핸들링 선언, SIGINT, SIGTERM에 대한 메세지 notify
 		signal.Notify(stopSig, syscall.SIGINT, syscall.SIGTERM)
 		<-stopSig //메세지 등록
@@ -63,6 +80,7 @@ func main() {
 		// 해당 context 타임아웃 설정, 5초후 server stop
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
+		stopScheduler()
 		if err := mapi.Shutdown(ctx); err != nil {
 			log.Fatal("Server Shutdown:", err)
 		}
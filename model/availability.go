@@ -0,0 +1,132 @@
+package model
+
+//availability.go : 메뉴 품절, 일일 한정 수량, 주문 가능 시간 데이터 핸들링
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// 메뉴 주문 가능 상태
+const (
+	MenuOrderable = "orderable"
+	MenuSoldOut   = "soldout"
+)
+
+var (
+	ErrSoldOut      = errors.New("menu is sold out")
+	ErrNotAvailable = errors.New("menu is not available at this time")
+)
+
+// 해당 시간에 주문 가능한 메뉴인지 확인 (품절, 주문 가능 시간)
+func (b BurgerKing) AvailableAt(t time.Time) error {
+	if b.Status == MenuSoldOut {
+		return ErrSoldOut
+	}
+	if len(b.AvailableFrom) <= 0 || len(b.AvailableTo) <= 0 {
+		return nil
+	}
+
+	now := t.Format("15:04")
+	if b.AvailableFrom <= b.AvailableTo { //ex. 06:00 ~ 10:30 아침 메뉴
+		if now < b.AvailableFrom || now >= b.AvailableTo {
+			return ErrNotAvailable
+		}
+	} else if now < b.AvailableFrom && now >= b.AvailableTo { //ex. 22:00 ~ 02:00 심야 메뉴
+		return ErrNotAvailable
+	}
+	return nil
+}
+
+// 주문시 메뉴 1개 수량 차감, 한정 수량이 없으면 품절 여부만 확인
+// t는 주문 시간(수량 차감 기준일), at은 메뉴를 받을 시간(주문 가능 시간 확인, 예약 주문)
+func (p *Model) ReserveStock(storeID primitive.ObjectID, menuName string, t, at time.Time) error {
+	burger, err := p.GetMenu(storeID, "menu", menuName)
+	if err != nil {
+		return ErrMenuNotFound
+	}
+	if err := burger.AvailableAt(at); err != nil {
+		return err
+	}
+	if burger.DailyStock <= 0 { //수량 제한 없음
+		return nil
+	}
+
+	today := t.Format("2006-01-02")
+	//날짜가 바뀌었으면 남은 수량을 일일 한정 수량으로 초기화
+	resetFilter := bson.M{"storeId": storeID, "menu": menuName, "deleted": notDeleted, "dailyStock": bson.M{"$gt": 0}, "stockDate": bson.M{"$ne": today}}
+	reset := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"stockLeft": "$dailyStock", "stockDate": today}}},
+	}
+	if _, err := p.colMenu.UpdateOne(context.TODO(), resetFilter, reset); err != nil {
+		return err
+	}
+
+	//남은 수량이 있을 때만 차감 (동시 주문시에도 음수가 되지 않음)
+	filter := bson.M{"storeId": storeID, "menu": menuName, "deleted": notDeleted, "status": bson.M{"$ne": MenuSoldOut}, "stockDate": today, "stockLeft": bson.M{"$gt": 0}}
+	update := bson.M{"$inc": bson.M{"stockLeft": -1}}
+	res, err := p.colMenu.UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrSoldOut
+	}
+	return nil
+}
+
+// 주문 실패, 변경시 차감했던 메뉴 수량 복구 (금일 차감분만)
+func (p *Model) ReleaseStock(storeID primitive.ObjectID, menuName string, t time.Time) error {
+	filter := bson.M{
+		"storeId":    storeID,
+		"menu":       menuName,
+		"deleted":    notDeleted,
+		"dailyStock": bson.M{"$gt": 0},
+		"stockDate":  t.Format("2006-01-02"),
+		"$expr":      bson.M{"$lt": bson.A{"$stockLeft", "$dailyStock"}},
+	}
+	update := bson.M{"$inc": bson.M{"stockLeft": 1}}
+	if _, err := p.colMenu.UpdateOne(context.TODO(), filter, update); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 메뉴 품절 상태 변경 (피주문자)
+func (p *Model) SetMenuStatus(storeID primitive.ObjectID, menuName, status string) error {
+	filter := bson.M{"storeId": storeID, "menu": menuName, "deleted": notDeleted}
+	update := bson.M{
+		"$set": bson.M{
+			"status": status,
+		},
+	}
+	if res, err := p.colMenu.UpdateOne(context.TODO(), filter, update); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrMenuNotFound
+	}
+	return nil
+}
+
+// 메뉴 일일 한정 수량 및 주문 가능 시간 변경 (피주문자), 수량 변경시 금일 남은 수량도 초기화
+func (p *Model) SetAvailability(storeID primitive.ObjectID, menuName string, dailyStock int, from, to string, t time.Time) error {
+	filter := bson.M{"storeId": storeID, "menu": menuName, "deleted": notDeleted}
+	update := bson.M{
+		"$set": bson.M{
+			"dailyStock":    dailyStock,
+			"stockLeft":     dailyStock,
+			"stockDate":     t.Format("2006-01-02"),
+			"availableFrom": from,
+			"availableTo":   to,
+		},
+	}
+	if res, err := p.colMenu.UpdateOne(context.TODO(), filter, update); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrMenuNotFound
+	}
+	return nil
+}
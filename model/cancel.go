@@ -0,0 +1,86 @@
+package model
+
+//cancel.go : 주문 취소(주문자), 주문 거절(피주문자) 및 환불 요청 데이터 핸들링
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 취소한 사람
+const (
+	CancelByCustomer = "customer"
+	CancelBySeller   = "seller"
+	CancelBySystem   = "system" //결제 대기 시간 초과 등 자동 취소
+)
+
+const ReasonPaymentTimeout = "paymentTimeout" //카드 결제 승인 대기 시간 초과
+
+// 주문 거절 사유 코드 (피주문자)
+const (
+	RejectSoldOut   = "soldOut"   //재료 소진
+	RejectTooBusy   = "tooBusy"   //주문 밀림
+	RejectClosing   = "closing"   //영업 종료 임박
+	RejectOutOfZone = "outOfZone" //배달 불가 지역
+	RejectOther     = "other"     //기타, note에 사유 작성
+)
+
+// 환불 상태
+const (
+	RefundPending   = "pending"   //환불 요청, 결제 처리 대기
+	RefundCompleted = "completed" //환불 완료
+	RefundFailed    = "failed"    //환불 실패, 재시도 필요
+)
+
+var rejectReasons = map[string]bool{RejectSoldOut: true, RejectTooBusy: true, RejectClosing: true, RejectOutOfZone: true, RejectOther: true}
+
+// 주문 취소 정보
+type Cancellation struct {
+	By     string    `bson:"by"`     //customer, seller, system
+	Reason string    `bson:"reason"` //거절 사유 코드, 주문자 취소는 customer
+	Note   string    `bson:"note"`   //상세 사유
+	At     time.Time `bson:"at"`     //취소 시간
+}
+
+// 환불 정보
+type Refund struct {
+	Amount      int       `bson:"amount"`                //환불 금액
+	Status      string    `bson:"status"`                //pending, completed, failed
+	RefundID    string    `bson:"refundId,omitempty"`    //결제 대행사 환불 번호
+	RequestedAt time.Time `bson:"requestedAt"`           //환불 요청 시간
+	CompletedAt time.Time `bson:"completedAt,omitempty"` //환불 완료 시간
+}
+
+// 주문 거절 사유 코드 확인
+func ValidRejectReason(reason string) bool {
+	return rejectReasons[reason]
+}
+
+// 취소 또는 거절된 주문인지 확인
+func (o OrderList) Cancelled() bool {
+	return o.State == StateCancelled || o.State == StateRejected
+}
+
+// 확인한 상태의 주문을 취소 또는 거절하고 환불 요청 기록 (결제 금액이 없으면 refund는 nil), 그 사이 상태가 바뀌었으면 에러
+func (p *Model) CancelOrder(orderID primitive.ObjectID, from string, cancel Cancellation, refund *Refund) (string, error) {
+	state := StateCancelled
+	if cancel.By == CancelBySeller {
+		state = StateRejected
+	}
+
+	filter := bson.M{"_id": orderID, "state": from}
+	set := bson.M{"state": state, "cancellation": cancel}
+	if refund != nil {
+		set["refund"] = refund
+	}
+	update := bson.M{"$set": set}
+	if res, err := p.colOrderList.UpdateOne(context.TODO(), filter, update); err != nil {
+		return state, err
+	} else if res.MatchedCount <= 0 {
+		return state, fmt.Errorf("There is no order %s in state %s", orderID.Hex(), from)
+	}
+	return state, nil
+}
@@ -0,0 +1,135 @@
+package model
+
+//combo.go : 세트 메뉴(버거 + 사이드 + 음료) 구성 및 주문시 구성 메뉴로 전개
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 메뉴 종류
+const (
+	MenuSingle = "single"
+	MenuCombo  = "combo"
+)
+
+// 세트 메뉴 구성 슬롯 ex. 사이드 : 감자튀김, 치즈스틱(+500)
+type ComboSlot struct {
+	Name     string       `bson:"name"`     //슬롯 이름 ex. 사이드
+	Optional bool         `bson:"optional"` //선택 안해도 되는 슬롯
+	Choices  []SlotChoice `bson:"choices"`  //선택 가능한 메뉴
+}
+
+type SlotChoice struct {
+	Menu     string `bson:"menu"`     //메뉴이름
+	Upcharge int    `bson:"upcharge"` //변경시 추가 금액
+}
+
+// 주문시 선택한 세트 구성
+type ComboChoice struct {
+	Slot string
+	Menu string
+}
+
+// 주문 메뉴에 포함된 세트 구성 메뉴, 주방 및 재고에서는 개별 메뉴로 처리
+type OrderComponent struct {
+	Slot     string `bson:"slot"`     //슬롯 이름
+	Menu     string `bson:"menu"`     //메뉴이름
+	Upcharge int    `bson:"upcharge"` //주문 시점의 추가 금액
+}
+
+// 세트 메뉴인지 확인
+func (b BurgerKing) IsCombo() bool {
+	return b.Type == MenuCombo
+}
+
+// 세트 구성 슬롯 정의 확인 (구성 메뉴 존재 여부는 제외)
+func ValidateComboSlots(slots []ComboSlot) error {
+	if len(slots) <= 0 {
+		return fmt.Errorf("combo must have at least one slot")
+	}
+	names := map[string]bool{}
+	for _, s := range slots {
+		if len(s.Name) <= 0 || names[s.Name] {
+			return fmt.Errorf("slot name must be unique and not empty, %q", s.Name)
+		}
+		names[s.Name] = true
+		if len(s.Choices) <= 0 {
+			return fmt.Errorf("%s : slot must have at least one choice", s.Name)
+		}
+
+		menus := map[string]bool{}
+		for _, ch := range s.Choices {
+			if len(ch.Menu) <= 0 || menus[ch.Menu] {
+				return fmt.Errorf("%s : choice menu must be unique and not empty, %q", s.Name, ch.Menu)
+			}
+			if ch.Upcharge < 0 {
+				return fmt.Errorf("%s : upcharge must be 0 or more, %s", s.Name, ch.Menu)
+			}
+			menus[ch.Menu] = true
+		}
+	}
+	return nil
+}
+
+// 선택한 세트 구성을 검증 후 주문 메뉴에 구성 메뉴와 추가 금액 반영
+func (b BurgerKing) AddComponents(item *OrderItem, choices []ComboChoice) error {
+	picked := map[string]ComboChoice{}
+	for _, ch := range choices {
+		if _, ok := picked[ch.Slot]; ok {
+			return fmt.Errorf("slot %s is selected twice", ch.Slot)
+		}
+		picked[ch.Slot] = ch
+	}
+
+	item.Components = []OrderComponent{}
+	for _, slot := range b.Slots {
+		ch, ok := picked[slot.Name]
+		if !ok {
+			if slot.Optional {
+				continue
+			}
+			return fmt.Errorf("select a menu for %s", slot.Name)
+		}
+		delete(picked, slot.Name)
+
+		choice, ok := slot.find(ch.Menu)
+		if !ok {
+			return fmt.Errorf("%s can not be selected for %s", ch.Menu, slot.Name)
+		}
+		item.Components = append(item.Components, OrderComponent{Slot: slot.Name, Menu: choice.Menu, Upcharge: choice.Upcharge})
+		item.Price += choice.Upcharge
+	}
+	for name := range picked {
+		return fmt.Errorf("%s has no slot %s", b.Menu, name)
+	}
+	return nil
+}
+
+func (s ComboSlot) find(menu string) (SlotChoice, bool) {
+	for _, ch := range s.Choices {
+		if ch.Menu == menu {
+			return ch, true
+		}
+	}
+	return SlotChoice{}, false
+}
+
+// 세트 구성 설정 (피주문자), 설정된 메뉴는 세트 메뉴가 됨
+func (p *Model) SetComboSlots(menuID primitive.ObjectID, slots []ComboSlot) error {
+	filter := bson.M{"_id": menuID, "deleted": notDeleted}
+	update := bson.M{
+		"$set": bson.M{
+			"type":  MenuCombo,
+			"slots": slots,
+		},
+	}
+	if res, err := p.colMenu.UpdateOne(context.TODO(), filter, update); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrMenuNotFound
+	}
+	return nil
+}
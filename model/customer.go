@@ -0,0 +1,149 @@
+package model
+
+//customer.go : 고객 정보 및 배달 주소록 데이터 핸들링
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	ErrCustomerNotFound = errors.New("customer not found")
+	ErrAddressNotFound  = errors.New("address not found")
+)
+
+type Customer struct {
+	ID               primitive.ObjectID `bson:"_id,omitempty"`              //고객 고유 번호
+	Pnum             string             `bson:"pnum"`                       //고객 번호
+	Name             string             `bson:"name"`                       //고객 이름
+	Addresses        []Address          `bson:"addresses"`                  //저장된 배달 주소
+	DefaultAddressID primitive.ObjectID `bson:"defaultAddressId,omitempty"` //기본 배달 주소
+	CreatedAt        string             `bson:"createdAt"`                  //등록 시간
+}
+
+// 배달 주소, 주문시 주문 내역에 그대로 복사해 이후 주소록 변경과 무관
+type Address struct {
+	ID         primitive.ObjectID `bson:"_id"`        //주소 고유 번호
+	Label      string             `bson:"label"`      //주소 이름 ex. 집, 회사
+	Street     string             `bson:"street"`     //도로명 주소
+	Detail     string             `bson:"detail"`     //상세 주소 ex. 101동 1001호
+	PostalCode string             `bson:"postalCode"` //우편번호
+	Lat        float64            `bson:"lat"`        //위도
+	Lng        float64            `bson:"lng"`        //경도
+	Notes      string             `bson:"notes"`      //배달 요청 사항
+}
+
+// 주문 내역에 표시할 한 줄 주소
+func (a Address) String() string {
+	return strings.TrimSpace(a.Street + " " + a.Detail)
+}
+
+// 주소록에서 주소 찾기
+func (c Customer) FindAddress(addressID primitive.ObjectID) (Address, error) {
+	for _, a := range c.Addresses {
+		if a.ID == addressID {
+			return a, nil
+		}
+	}
+	return Address{}, ErrAddressNotFound
+}
+
+// 기본 배달 주소, 없으면 ErrAddressNotFound
+func (c Customer) DefaultAddress() (Address, error) {
+	return c.FindAddress(c.DefaultAddressID)
+}
+
+// 고객 번호로 고객 정보 조회
+func (p *Model) GetCustomer(pnum string) (Customer, error) {
+	filter := bson.M{"pnum": pnum}
+
+	var customer Customer
+	if err := p.colCustomer.FindOne(context.TODO(), filter).Decode(&customer); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return customer, ErrCustomerNotFound
+		}
+		return customer, err
+	}
+	return customer, nil
+}
+
+// 고객 이름 변경, 처음이면 고객 정보 생성
+func (p *Model) SaveCustomer(pnum, name, createdAt string) error {
+	filter := bson.M{"pnum": pnum}
+	update := bson.M{
+		"$set":         bson.M{"name": name},
+		"$setOnInsert": bson.M{"addresses": []Address{}, "createdAt": createdAt},
+	}
+	if _, err := p.colCustomer.UpdateOne(context.TODO(), filter, update, options.Update().SetUpsert(true)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 주소록에 주소 추가, 처음 추가한 주소나 makeDefault면 기본 주소로 설정
+func (p *Model) AddAddress(pnum string, addr Address, makeDefault bool, createdAt string) (primitive.ObjectID, error) {
+	addr.ID = primitive.NewObjectID()
+
+	filter := bson.M{"pnum": pnum}
+	update := bson.M{
+		"$push":        bson.M{"addresses": addr},
+		"$setOnInsert": bson.M{"name": "", "createdAt": createdAt},
+	}
+	if _, err := p.colCustomer.UpdateOne(context.TODO(), filter, update, options.Update().SetUpsert(true)); err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	if !makeDefault { //기본 주소가 없을 때만 설정
+		filter["defaultAddressId"] = bson.M{"$exists": false}
+	}
+	if _, err := p.colCustomer.UpdateOne(context.TODO(), filter, bson.M{"$set": bson.M{"defaultAddressId": addr.ID}}); err != nil {
+		return addr.ID, err
+	}
+	return addr.ID, nil
+}
+
+// 주소록의 주소 변경, 이전 주문 내역의 주소는 변경되지 않음
+func (p *Model) UpdateAddress(pnum string, addr Address) error {
+	filter := bson.M{"pnum": pnum, "addresses._id": addr.ID}
+	update := bson.M{"$set": bson.M{"addresses.$": addr}}
+	if res, err := p.colCustomer.UpdateOne(context.TODO(), filter, update); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrAddressNotFound
+	}
+	return nil
+}
+
+// 주소록에서 주소 삭제, 기본 주소였으면 기본 주소 해제
+func (p *Model) DeleteAddress(pnum string, addressID primitive.ObjectID) error {
+	filter := bson.M{"pnum": pnum, "addresses._id": addressID}
+	update := bson.M{"$pull": bson.M{"addresses": bson.M{"_id": addressID}}}
+	if res, err := p.colCustomer.UpdateOne(context.TODO(), filter, update); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrAddressNotFound
+	}
+
+	filter = bson.M{"pnum": pnum, "defaultAddressId": addressID}
+	if _, err := p.colCustomer.UpdateOne(context.TODO(), filter, bson.M{"$unset": bson.M{"defaultAddressId": ""}}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 기본 배달 주소 변경
+func (p *Model) SetDefaultAddress(pnum string, addressID primitive.ObjectID) error {
+	filter := bson.M{"pnum": pnum, "addresses._id": addressID}
+	update := bson.M{"$set": bson.M{"defaultAddressId": addressID}}
+	if res, err := p.colCustomer.UpdateOne(context.TODO(), filter, update); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrAddressNotFound
+	}
+	return nil
+}
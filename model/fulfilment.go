@@ -0,0 +1,85 @@
+package model
+
+//fulfilment.go : 주문 수령 방법(배달, 포장, 매장 식사)별 주문 상태 흐름 확인
+import (
+	"errors"
+	"fmt"
+)
+
+// 주문 수령 방법
+const (
+	FulfilmentDelivery = "delivery" //배달
+	FulfilmentPickup   = "pickup"   //포장 주문 후 매장에서 수령
+	FulfilmentDineIn   = "dineIn"   //매장 식사
+)
+
+var ErrInvalidState = errors.New("invalid order state")
+
+// 수령 방법별 주문 상태 순서, 예약 주문은 예약접수 후 접수중부터 진행
+var stateFlows = map[string][]string{
+	FulfilmentDelivery: {StateScheduled, StateReceived, StateCooking, StateReady, StateDelivering, StateDelivered},
+	FulfilmentPickup:   {StateScheduled, StateReceived, StateCooking, StateReadyForPickup, StatePickedUp},
+	FulfilmentDineIn:   {StateScheduled, StateReceived, StateCooking, StateReady, StateServed},
+}
+
+// 수령 방법 확인
+func ValidFulfilment(fulfilment string) bool {
+	_, ok := stateFlows[fulfilment]
+	return ok
+}
+
+// 주문 수령 방법, 수령 방법이 없는 이전 주문은 배달
+func (o OrderList) FulfilmentType() string {
+	if len(o.Fulfilment) <= 0 {
+		return FulfilmentDelivery
+	}
+	return o.Fulfilment
+}
+
+// 수령 방법의 상태 순서에서 현재 상태 위치, 없으면 -1
+func (o OrderList) stateIndex(state string) int {
+	for i, s := range stateFlows[o.FulfilmentType()] {
+		if s == state {
+			return i
+		}
+	}
+	return -1
+}
+
+// 변경할 상태 확인, 수령 방법의 상태 순서에서 앞으로만 변경 가능 (단계 건너뛰기 가능). 취소 및 결제 대기 주문은 변경 불가
+func (o OrderList) CheckStateChange(state string) error {
+	if o.Cancelled() || o.State == StatePendingPayment {
+		return fmt.Errorf("%w, %s order can not be changed", ErrInvalidState, o.State)
+	}
+	to := o.stateIndex(state)
+	if to < 0 {
+		return fmt.Errorf("%w, %s order can not be %s", ErrInvalidState, o.FulfilmentType(), state)
+	}
+	if from := o.stateIndex(o.State); from >= 0 && to <= from {
+		return fmt.Errorf("%w, can not change %s to %s", ErrInvalidState, o.State, state)
+	}
+	return nil
+}
+
+// 조리가 끝난 주문인지 확인 (조리완료, 픽업대기 등 조리중 이후 상태)
+func (o OrderList) Cooked() bool {
+	return o.stateIndex(o.State) > o.stateIndex(StateCooking)
+}
+
+// 수령까지 끝난 주문인지 확인 (배달완료, 픽업완료, 서빙완료)
+func (o OrderList) Completed() bool {
+	flow := stateFlows[o.FulfilmentType()]
+	return len(flow) > 0 && o.State == flow[len(flow)-1]
+}
+
+// 주방 화면에서 넘길 다음 상태, 접수중은 조리중으로, 조리중은 조리완료(포장 주문은 픽업대기)로
+func (o OrderList) NextKitchenState() (string, error) {
+	flow := stateFlows[o.FulfilmentType()]
+	switch o.State {
+	case StateReceived:
+		return StateCooking, nil
+	case StateCooking:
+		return flow[o.stateIndex(StateCooking)+1], nil
+	}
+	return "", fmt.Errorf("%w, %s order is not in the kitchen", ErrInvalidState, o.State)
+}
@@ -0,0 +1,143 @@
+package model
+
+//hours.go : 사업장 영업 시간, 휴무일, 주문 일시 중지 확인 및 데이터 핸들링
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 시간대를 지정하지 않은 사업장의 기본 시간대
+const DefaultTimeZone = "Asia/Seoul"
+
+var (
+	ErrStoreClosed  = errors.New("store is closed")
+	ErrStoreHoliday = errors.New("store is closed for holiday")
+	ErrStorePaused  = errors.New("store paused taking orders")
+)
+
+// 휴무일, 사업장 시간대 기준 하루 전체 휴무
+type Holiday struct {
+	Date   string `bson:"date"`   //YYYY-MM-DD
+	Reason string `bson:"reason"` //휴무 사유
+}
+
+// 시간대 확인
+func ValidateTimeZone(name string) error {
+	if _, err := time.LoadLocation(name); err != nil || len(name) <= 0 {
+		return fmt.Errorf("unknown timeZone, %s", name)
+	}
+	return nil
+}
+
+// 휴무일 정의 확인
+func ValidateHolidays(holidays []Holiday) error {
+	for _, h := range holidays {
+		if _, err := time.Parse("2006-01-02", h.Date); err != nil {
+			return fmt.Errorf("holiday date must be YYYY-MM-DD, %s", h.Date)
+		}
+	}
+	return nil
+}
+
+// 사업장 시간대, 지정하지 않았거나 잘못된 경우 기본 시간대
+func (s Store) Location() *time.Location {
+	if len(s.TimeZone) > 0 {
+		if loc, err := time.LoadLocation(s.TimeZone); err == nil {
+			return loc
+		}
+	}
+	if loc, err := time.LoadLocation(DefaultTimeZone); err == nil {
+		return loc
+	}
+	return time.Local
+}
+
+// 해당 시간에 영업중인지 확인 (휴무일, 요일별 영업 시간), 영업 시간이 없으면 항상 영업
+func (s Store) OpenAt(t time.Time) error {
+	t = t.In(s.Location())
+	date := t.Format("2006-01-02")
+	for _, h := range s.Holidays {
+		if h.Date == date {
+			return ErrStoreHoliday
+		}
+	}
+	if len(s.OpeningHours) <= 0 {
+		return nil
+	}
+
+	now := t.Format("15:04")
+	today := int(t.Weekday())
+	yesterday := (today + 6) % 7
+	for _, h := range s.OpeningHours {
+		overnight := h.Close <= h.Open //자정을 넘기는 영업, 같으면 24시간 영업
+		if h.Weekday == today {
+			if now >= h.Open && (overnight || now < h.Close) {
+				return nil
+			}
+		} else if h.Weekday == yesterday && overnight && now < h.Close { //전날 시작한 영업
+			return nil
+		}
+	}
+	return ErrStoreClosed
+}
+
+// t 이후 가장 빠른 영업 시작 시간, 14일 이내에 없으면 false
+func (s Store) NextOpen(t time.Time) (time.Time, bool) {
+	t = t.In(s.Location())
+	if s.OpenAt(t) == nil {
+		return t, true
+	}
+
+	var next time.Time
+	for d := 0; d <= 14; d++ {
+		day := t.AddDate(0, 0, d)
+		if len(s.OpeningHours) <= 0 && d > 0 { //영업 시간이 없으면 휴무일 다음날 0시
+			midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, t.Location())
+			if s.OpenAt(midnight) == nil {
+				return midnight, true
+			}
+			continue
+		}
+		for _, h := range s.OpeningHours {
+			if h.Weekday != int(day.Weekday()) {
+				continue
+			}
+			open, err := time.ParseInLocation("2006-01-02 15:04", day.Format("2006-01-02")+" "+h.Open, t.Location())
+			if err != nil || open.Before(t) || s.OpenAt(open) != nil {
+				continue
+			}
+			if next.IsZero() || open.Before(next) {
+				next = open
+			}
+		}
+		if !next.IsZero() {
+			return next, true
+		}
+	}
+	return next, false
+}
+
+// 주문 일시 중지 및 재개 (피주문자)
+func (p *Model) SetStorePaused(storeID primitive.ObjectID, paused bool, reason string) error {
+	if !paused {
+		reason = ""
+	}
+	filter := bson.M{"_id": storeID}
+	update := bson.M{
+		"$set": bson.M{
+			"paused":      paused,
+			"pauseReason": reason,
+		},
+	}
+	if res, err := p.colStore.UpdateOne(context.TODO(), filter, update); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrStoreNotFound
+	}
+	return nil
+}
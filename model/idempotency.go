@@ -0,0 +1,71 @@
+package model
+
+//idempotency.go : 주문 등 재시도 요청의 Idempotency-Key와 처음 응답 저장, 만료 시간이 지나면 자동 삭제
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// 키 처리 상태
+const (
+	IdempotencyProcessing = "processing" //처음 요청 처리중
+	IdempotencyDone       = "done"       //처음 응답 저장 완료
+)
+
+var ErrIdempotencyKeyExists = errors.New("idempotency key already used")
+
+// 요청 키별 처음 요청 내용과 응답
+type IdempotencyKey struct {
+	Key         string    `bson:"_id"`         //계정:고객 번호:경로:Idempotency-Key
+	RequestHash string    `bson:"requestHash"` //요청 내용 hash, 같은 키로 다른 요청이면 거부
+	Status      string    `bson:"status"`      //processing, done
+	RespStatus  int       `bson:"respStatus"`  //처음 응답 status
+	RespBody    []byte    `bson:"respBody"`    //처음 응답 body
+	CreatedAt   time.Time `bson:"createdAt"`   //처음 요청 시간
+	LeaseUntil  time.Time `bson:"leaseUntil"`  //처리중 상태 유지 시간, 지나면 서버 중단으로 보고 같은 요청 재시도 허용
+	ExpireAt    time.Time `bson:"expireAt"`    //만료 시간, TTL 인덱스로 삭제
+}
+
+// 키 선점, 이미 있는 키면 저장된 내용과 ErrIdempotencyKeyExists 반환
+// 처리중 상태로 leaseUntil이 지난 같은 요청은 다시 선점
+func (p *Model) ReserveIdempotencyKey(key, requestHash string, now, leaseUntil, expireAt time.Time) (IdempotencyKey, error) {
+	doc := IdempotencyKey{Key: key, RequestHash: requestHash, Status: IdempotencyProcessing, CreatedAt: now, LeaseUntil: leaseUntil, ExpireAt: expireAt}
+	_, err := p.colIdempotency.InsertOne(context.TODO(), doc)
+	if err == nil {
+		return doc, nil
+	}
+	if !mongo.IsDuplicateKeyError(err) {
+		return doc, err
+	}
+
+	filter := bson.M{"_id": key, "requestHash": requestHash, "status": IdempotencyProcessing, "leaseUntil": bson.M{"$lt": now}}
+	update := bson.M{"$set": bson.M{"createdAt": now, "leaseUntil": leaseUntil, "expireAt": expireAt}}
+	if res, err := p.colIdempotency.UpdateOne(context.TODO(), filter, update); err != nil {
+		return doc, err
+	} else if res.MatchedCount > 0 {
+		return doc, nil
+	}
+
+	var saved IdempotencyKey
+	if err := p.colIdempotency.FindOne(context.TODO(), bson.M{"_id": key}).Decode(&saved); err != nil {
+		return saved, err
+	}
+	return saved, ErrIdempotencyKeyExists
+}
+
+// 처음 응답 저장, 이후 같은 키 요청은 저장된 응답 반환
+func (p *Model) SaveIdempotentResponse(key string, status int, body []byte) error {
+	update := bson.M{"$set": bson.M{"status": IdempotencyDone, "respStatus": status, "respBody": body}}
+	_, err := p.colIdempotency.UpdateOne(context.TODO(), bson.M{"_id": key}, update)
+	return err
+}
+
+// 키 삭제, 처리 실패로 다시 시도 가능하게 함
+func (p *Model) ReleaseIdempotencyKey(key string) error {
+	_, err := p.colIdempotency.DeleteOne(context.TODO(), bson.M{"_id": key})
+	return err
+}
@@ -0,0 +1,41 @@
+package model
+
+//kitchen.go : 주방 화면 주문 조회 및 주문 확인 데이터 핸들링
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var ErrOrderNotFound = errors.New("order not found")
+
+// 주방에서 처리중인 주문 (접수중, 조리중) 주문 시간순 조회
+func (p *Model) GetKitchenOrders(storeID primitive.ObjectID) ([]OrderList, error) {
+	filter := bson.M{"storeId": storeID, "state": bson.M{"$in": bson.A{StateReceived, StateCooking}}}
+	opts := options.Find().SetSort(bson.D{{Key: "orderTime", Value: 1}})
+
+	cursor, err := p.colOrderList.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	orders := []OrderList{}
+	if err := cursor.All(context.TODO(), &orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// 주방에서 주문 확인, 이미 확인한 주문은 처음 확인 시간 유지
+func (p *Model) AckOrder(storeID, orderID primitive.ObjectID, t time.Time) error {
+	filter := bson.M{"_id": orderID, "storeId": storeID}
+	if res, err := p.colOrderList.UpdateOne(context.TODO(), filter, bson.M{"$min": bson.M{"ackedAt": t}}); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrOrderNotFound
+	}
+	return nil
+}
@@ -0,0 +1,373 @@
+package model
+
+//loyalty.go : 적립금 및 스탬프 카드, 추가만 가능한 원장 기록으로 잔액 계산
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 원장 기록 종류
+const (
+	LedgerEarn         = "earn"         //주문 수령시 적립금 적립
+	LedgerRedeem       = "redeem"       //주문시 적립금 사용
+	LedgerRestore      = "restore"      //주문 실패 및 취소시 사용한 적립금 복구
+	LedgerExpire       = "expire"       //유효 기간이 지난 적립금 소멸
+	LedgerStamp        = "stamp"        //주문 수령시 스탬프 적립
+	LedgerStampRedeem  = "stampRedeem"  //스탬프 보상 메뉴 사용
+	LedgerStampRestore = "stampRestore" //주문 실패 및 취소시 사용한 스탬프 복구
+)
+
+var (
+	ErrLoyaltyConflict   = errors.New("loyalty ledger is busy, try again")
+	ErrNotEnoughPoints   = errors.New("not enough points")
+	ErrNotEnoughStamps   = errors.New("not enough stamps")
+	ErrStampCardNotFound = errors.New("stamp card not found")
+	ErrStampCardInactive = errors.New("stamp card is not active now")
+)
+
+// 적립금 및 스탬프 원장 기록, 수정 및 삭제 없이 추가만 하고 잔액은 기록을 순서대로 더해 계산
+type LedgerEntry struct {
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Pnum        string             `bson:"pnum"`                  //고객
+	Seq         int                `bson:"seq"`                   //고객별 기록 순번, 같은 순번은 하나만 기록되어 동시 기록 방지
+	Type        string             `bson:"type"`                  //earn, redeem, restore, expire, stamp, stampRedeem, stampRestore
+	Points      int                `bson:"points"`                //적립금 증감
+	Stamps      int                `bson:"stamps"`                //스탬프 증감
+	StampCardID primitive.ObjectID `bson:"stampCardId,omitempty"` //스탬프 카드
+	StoreID     primitive.ObjectID `bson:"storeId,omitempty"`     //주문 사업장
+	OrderID     primitive.ObjectID `bson:"orderId,omitempty"`     //적립 및 사용 주문
+	RefSeq      int                `bson:"refSeq,omitempty"`      //expire는 소멸된, restore는 복구한 적립 기록 순번
+	ExpireAt    time.Time          `bson:"expireAt,omitempty"`    //적립금 만료 시간, earn 및 restore
+	CreatedAt   time.Time          `bson:"createdAt"`             //기록 시간
+}
+
+// 스탬프 카드 캠페인, Goal개를 모으면 RewardMenu 1개 무료
+type StampCard struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"` //스탬프 카드 고유 번호
+	StoreID    primitive.ObjectID `bson:"storeId"`       //사업장
+	Name       string             `bson:"name"`          //캠페인 이름
+	Menu       string             `bson:"menu"`          //스탬프 적립 메뉴, 비어있으면 주문 1건당 1개
+	Goal       int                `bson:"goal"`          //보상에 필요한 스탬프 수 (10번째 무료는 9)
+	RewardMenu string             `bson:"rewardMenu"`    //보상 메뉴
+	StartAt    time.Time          `bson:"startAt"`       //적립 및 사용 시작 시간
+	EndAt      time.Time          `bson:"endAt"`         //적립 및 사용 종료 시간
+	Retired    bool               `bson:"retired"`       //사용 중지
+	CreatedAt  string             `bson:"createdAt"`     //등록 시간
+}
+
+// 아직 남아있는 적립금 묶음, 먼저 적립된 묶음부터 사용
+type PointLot struct {
+	Seq      int       //적립 기록 순번
+	Points   int       //남은 적립금
+	ExpireAt time.Time //만료 시간
+}
+
+// 원장으로 계산한 고객 적립금 및 스탬프 잔액
+type LoyaltyAccount struct {
+	Pnum     string
+	Points   int            //사용 가능한 적립금
+	Lots     []PointLot     //만료 시간별 남은 적립금, 만료 임박순
+	Stamps   map[string]int //스탬프 카드별 스탬프 수
+	seq      int
+	entries  []LedgerEntry
+	expired  []PointLot                        //만료되었지만 소멸 기록이 없는 적립금
+	redeemed map[primitive.ObjectID][]PointLot //주문별 사용한 적립 묶음과 사용한 적립금
+}
+
+// 스탬프 카드 정의 확인
+func (s StampCard) Validate() error {
+	if len(s.Name) <= 0 || len(s.RewardMenu) <= 0 {
+		return fmt.Errorf("name and rewardMenu not found")
+	}
+	if s.Goal <= 0 {
+		return fmt.Errorf("goal must be more than 0")
+	}
+	if !s.EndAt.After(s.StartAt) {
+		return fmt.Errorf("endAt must be after startAt")
+	}
+	return nil
+}
+
+// 적립 및 사용 가능한 기간인지 확인
+func (s StampCard) Active(now time.Time) bool {
+	return !s.Retired && !now.Before(s.StartAt) && now.Before(s.EndAt)
+}
+
+// 주문으로 받는 스탬프 수, 무료 메뉴(보상, 증정)는 제외
+func (s StampCard) StampsFor(order OrderList) int {
+	if len(s.Menu) <= 0 {
+		return 1
+	}
+	count := 0
+	for _, item := range order.Items {
+		if item.Menu == s.Menu && item.Price > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// 적립금 할인, 쿠폰 또는 스탬프 보상을 사용한 주문인지 확인
+func (o OrderList) Discounted() bool {
+	return !o.PromotionID.IsZero() || o.PointsUsed > 0 || !o.StampCardID.IsZero()
+}
+
+// 원장 기록을 순서대로 계산, 사용한 적립금은 그 시점에 만료되지 않은 가장 오래된 묶음에서 차감
+func replayLedger(pnum string, entries []LedgerEntry, now time.Time) LoyaltyAccount {
+	acc := LoyaltyAccount{Pnum: pnum, Stamps: map[string]int{}, entries: entries, redeemed: map[primitive.ObjectID][]PointLot{}}
+	lots := []PointLot{}
+	for _, e := range entries {
+		acc.seq = e.Seq
+		switch e.Type {
+		case LedgerEarn, LedgerRestore:
+			lots = append(lots, PointLot{Seq: e.Seq, Points: e.Points, ExpireAt: e.ExpireAt})
+		case LedgerRedeem:
+			need := -e.Points
+			for i := range lots {
+				if need <= 0 {
+					break
+				}
+				if lots[i].Points <= 0 || !lots[i].ExpireAt.After(e.CreatedAt) {
+					continue
+				}
+				use := lots[i].Points
+				if use > need {
+					use = need
+				}
+				lots[i].Points -= use
+				need -= use
+				acc.redeemed[e.OrderID] = append(acc.redeemed[e.OrderID], PointLot{Seq: lots[i].Seq, Points: use, ExpireAt: lots[i].ExpireAt})
+			}
+		case LedgerExpire:
+			for i := range lots {
+				if lots[i].Seq == e.RefSeq {
+					lots[i].Points = 0
+				}
+			}
+		}
+		if e.Stamps != 0 {
+			acc.Stamps[e.StampCardID.Hex()] += e.Stamps
+		}
+	}
+
+	for _, lot := range lots {
+		if lot.Points <= 0 {
+			continue
+		}
+		if lot.ExpireAt.After(now) {
+			acc.Points += lot.Points
+			acc.Lots = append(acc.Lots, lot)
+		} else {
+			acc.expired = append(acc.expired, lot)
+		}
+	}
+	sort.SliceStable(acc.Lots, func(i, j int) bool { return acc.Lots[i].ExpireAt.Before(acc.Lots[j].ExpireAt) })
+	return acc
+}
+
+// 주문의 원장 기록 종류별 합계
+func (acc LoyaltyAccount) orderEntries(orderID primitive.ObjectID) map[string]LedgerEntry {
+	sums := map[string]LedgerEntry{}
+	for _, e := range acc.entries {
+		if e.OrderID != orderID {
+			continue
+		}
+		sum := sums[e.Type]
+		sum.Type, sum.StampCardID = e.Type, e.StampCardID
+		sum.Points += e.Points
+		sum.Stamps += e.Stamps
+		sums[e.Type] = sum
+	}
+	return sums
+}
+
+// 주문에 사용한 적립금과 스탬프의 복구 기록, 적립금은 사용한 묶음별로 원래 만료 시간까지 사용 가능
+// 이미 만료된 묶음은 복구하지 않음
+func (acc LoyaltyAccount) restoreEntries(order OrderList, now time.Time) []LedgerEntry {
+	sums := acc.orderEntries(order.ID)
+	add := []LedgerEntry{}
+
+	restored := map[int]int{} //적립 묶음별 복구한 적립금
+	for _, e := range acc.entries {
+		if e.OrderID == order.ID && e.Type == LedgerRestore {
+			restored[e.RefSeq] += e.Points
+		}
+	}
+	for _, lot := range acc.redeemed[order.ID] {
+		points := lot.Points - restored[lot.Seq]
+		if points <= 0 || !lot.ExpireAt.After(now) {
+			continue
+		}
+		add = append(add, LedgerEntry{Type: LedgerRestore, Points: points, StoreID: order.StoreID, OrderID: order.ID, RefSeq: lot.Seq, ExpireAt: lot.ExpireAt})
+	}
+
+	if used := -(sums[LedgerStampRedeem].Stamps + sums[LedgerStampRestore].Stamps); used > 0 {
+		add = append(add, LedgerEntry{Type: LedgerStampRestore, Stamps: used, StampCardID: sums[LedgerStampRedeem].StampCardID, StoreID: order.StoreID, OrderID: order.ID})
+	}
+	return add
+}
+
+// 고객 원장 기록 순번순 조회
+func (p *Model) GetLedger(pnum string) ([]LedgerEntry, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "seq", Value: 1}})
+	cursor, err := p.colLedger.Find(context.TODO(), bson.M{"pnum": pnum}, opts)
+	if err != nil {
+		return nil, err
+	}
+	entries := []LedgerEntry{}
+	if err := cursor.All(context.TODO(), &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// 원장 기록 추가, 만료된 적립금 소멸 기록을 먼저 남기고 build로 만든 기록을 다음 순번으로 추가
+// 다른 요청이 같은 순번을 먼저 기록했으면 잔액을 다시 계산해 재시도
+func (p *Model) appendLedger(pnum string, now time.Time, build func(acc LoyaltyAccount) ([]LedgerEntry, error)) (LoyaltyAccount, error) {
+	for try := 0; try < 5; try++ {
+		entries, err := p.GetLedger(pnum)
+		if err != nil {
+			return LoyaltyAccount{}, err
+		}
+		acc := replayLedger(pnum, entries, now)
+
+		add := []LedgerEntry{}
+		for _, lot := range acc.expired {
+			add = append(add, LedgerEntry{Type: LedgerExpire, Points: -lot.Points, RefSeq: lot.Seq})
+		}
+		more, err := build(acc)
+		if err != nil {
+			return acc, err
+		}
+		add = append(add, more...)
+		if len(add) <= 0 {
+			return acc, nil
+		}
+
+		docs := make([]interface{}, len(add))
+		for i := range add {
+			add[i].Pnum, add[i].Seq, add[i].CreatedAt = pnum, acc.seq+i+1, now
+			docs[i] = add[i]
+		}
+		if _, err := p.colLedger.InsertMany(context.TODO(), docs); mongo.IsDuplicateKeyError(err) {
+			continue
+		} else if err != nil {
+			return acc, err
+		}
+		return replayLedger(pnum, append(entries, add...), now), nil
+	}
+	return LoyaltyAccount{}, ErrLoyaltyConflict
+}
+
+// 고객 적립금 및 스탬프 잔액 조회, 만료된 적립금은 소멸 기록
+func (p *Model) GetLoyalty(pnum string, now time.Time) (LoyaltyAccount, error) {
+	return p.appendLedger(pnum, now, func(acc LoyaltyAccount) ([]LedgerEntry, error) { return nil, nil })
+}
+
+// 수령 완료 주문 적립, 주문당 한 번만 적립
+func (p *Model) EarnLoyalty(order OrderList, points int, expireAt time.Time, cards []StampCard, now time.Time) error {
+	_, err := p.appendLedger(order.Pnum, now, func(acc LoyaltyAccount) ([]LedgerEntry, error) {
+		sums := acc.orderEntries(order.ID)
+		if _, ok := sums[LedgerEarn]; ok {
+			return nil, nil
+		}
+		if _, ok := sums[LedgerStamp]; ok {
+			return nil, nil
+		}
+
+		add := []LedgerEntry{}
+		if points > 0 {
+			add = append(add, LedgerEntry{Type: LedgerEarn, Points: points, StoreID: order.StoreID, OrderID: order.ID, ExpireAt: expireAt})
+		}
+		for _, card := range cards {
+			if stamps := card.StampsFor(order); stamps > 0 && card.Active(now) {
+				add = append(add, LedgerEntry{Type: LedgerStamp, Stamps: stamps, StampCardID: card.ID, StoreID: order.StoreID, OrderID: order.ID})
+			}
+		}
+		return add, nil
+	})
+	return err
+}
+
+// 주문시 적립금 및 스탬프 사용, 잔액이 부족하면 기록하지 않음
+func (p *Model) RedeemLoyalty(order OrderList, points int, card *StampCard, now time.Time) error {
+	_, err := p.appendLedger(order.Pnum, now, func(acc LoyaltyAccount) ([]LedgerEntry, error) {
+		if acc.Points < points {
+			return nil, ErrNotEnoughPoints
+		}
+		add := []LedgerEntry{}
+		if card != nil {
+			if acc.Stamps[card.ID.Hex()] < card.Goal {
+				return nil, ErrNotEnoughStamps
+			}
+			add = append(add, LedgerEntry{Type: LedgerStampRedeem, Stamps: -card.Goal, StampCardID: card.ID, StoreID: order.StoreID, OrderID: order.ID})
+		}
+		if points > 0 {
+			add = append(add, LedgerEntry{Type: LedgerRedeem, Points: -points, StoreID: order.StoreID, OrderID: order.ID})
+		}
+		return add, nil
+	})
+	return err
+}
+
+// 주문 실패 및 취소시 사용한 적립금과 스탬프 복구, 적립금은 사용한 묶음의 원래 만료 시간 유지
+func (p *Model) RestoreLoyalty(order OrderList, now time.Time) error {
+	_, err := p.appendLedger(order.Pnum, now, func(acc LoyaltyAccount) ([]LedgerEntry, error) {
+		return acc.restoreEntries(order, now), nil
+	})
+	return err
+}
+
+// 스탬프 카드 등록 (피주문자)
+func (p *Model) CreateStampCard(card StampCard) (primitive.ObjectID, error) {
+	res, err := p.colStampCard.InsertOne(context.TODO(), card)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return res.InsertedID.(primitive.ObjectID), nil
+}
+
+// 사업장 스탬프 카드 조회
+func (p *Model) GetStampCard(storeID, cardID primitive.ObjectID) (StampCard, error) {
+	var card StampCard
+	if err := p.colStampCard.FindOne(context.TODO(), bson.M{"_id": cardID, "storeId": storeID}).Decode(&card); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return card, ErrStampCardNotFound
+		}
+		return card, err
+	}
+	return card, nil
+}
+
+// 사업장 스탬프 카드 최신순 조회
+func (p *Model) GetStampCards(storeID primitive.ObjectID) ([]StampCard, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}})
+	cursor, err := p.colStampCard.Find(context.TODO(), bson.M{"storeId": storeID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	cards := []StampCard{}
+	if err := cursor.All(context.TODO(), &cards); err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
+
+// 스탬프 카드 사용 중지 (피주문자), 모은 스탬프는 원장에 남음
+func (p *Model) RetireStampCard(storeID, cardID primitive.ObjectID) error {
+	filter := bson.M{"_id": cardID, "storeId": storeID}
+	if res, err := p.colStampCard.UpdateOne(context.TODO(), filter, bson.M{"$set": bson.M{"retired": true}}); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrStampCardNotFound
+	}
+	return nil
+}
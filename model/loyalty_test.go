@@ -0,0 +1,58 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestRestoreEntries(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	soon, later := base.AddDate(0, 1, 0), base.AddDate(1, 0, 0)
+	order := OrderList{ID: primitive.NewObjectID(), Pnum: "01012345678"}
+	ledger := []LedgerEntry{
+		{Seq: 1, Type: LedgerEarn, Points: 300, ExpireAt: soon, CreatedAt: base},
+		{Seq: 2, Type: LedgerEarn, Points: 500, ExpireAt: later, CreatedAt: base},
+		{Seq: 3, Type: LedgerRedeem, Points: -600, OrderID: order.ID, CreatedAt: base.Add(time.Hour)}, //1번 300, 2번 300 사용
+	}
+
+	tests := []struct {
+		name    string
+		entries []LedgerEntry
+		now     time.Time
+		want    []LedgerEntry
+	}{
+		{"restore each lot with original expiry", ledger, base.Add(2 * time.Hour), []LedgerEntry{
+			{Points: 300, RefSeq: 1, ExpireAt: soon},
+			{Points: 300, RefSeq: 2, ExpireAt: later},
+		}},
+		{"skip expired lot", ledger, soon.Add(time.Hour), []LedgerEntry{
+			{Points: 300, RefSeq: 2, ExpireAt: later},
+		}},
+		{"already restored", append(append([]LedgerEntry{}, ledger...),
+			LedgerEntry{Seq: 4, Type: LedgerRestore, Points: 300, OrderID: order.ID, RefSeq: 1, ExpireAt: soon},
+			LedgerEntry{Seq: 5, Type: LedgerRestore, Points: 300, OrderID: order.ID, RefSeq: 2, ExpireAt: later},
+		), base.Add(2 * time.Hour), nil},
+		{"partly restored", append(append([]LedgerEntry{}, ledger...),
+			LedgerEntry{Seq: 4, Type: LedgerRestore, Points: 300, OrderID: order.ID, RefSeq: 1, ExpireAt: soon},
+		), base.Add(2 * time.Hour), []LedgerEntry{
+			{Points: 300, RefSeq: 2, ExpireAt: later},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			acc := replayLedger(order.Pnum, tt.entries, tt.now)
+			got := acc.restoreEntries(order, tt.now)
+			if len(got) != len(tt.want) {
+				t.Fatalf("restoreEntries() = %+v, want %d entries", got, len(tt.want))
+			}
+			for i, w := range tt.want {
+				g := got[i]
+				if g.Type != LedgerRestore || g.Points != w.Points || g.RefSeq != w.RefSeq || !g.ExpireAt.Equal(w.ExpireAt) {
+					t.Errorf("entry %d = %+v, want %+v", i, g, w)
+				}
+			}
+		})
+	}
+}
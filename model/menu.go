@@ -0,0 +1,89 @@
+package model
+
+//menu.go : 메뉴 상세 정보 및 부분 수정 항목
+import (
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// 재료별 원산지
+type Origin struct {
+	Ingredient string `bson:"ingredient"` //재료 ex. 소고기
+	Country    string `bson:"country"`    //원산지 ex. 호주산
+}
+
+// 메뉴 부분 수정 항목, nil이 아닌 항목만 변경
+type MenuPatch struct {
+	Price       *int
+	Recommend   *int
+	Category    *string
+	Description *string
+	Image       *string
+	Origins     *[]Origin
+	Spiciness   *int
+	Allergens   *[]string
+	Calories    *int
+}
+
+// 변경할 항목의 $set 내용
+func (m MenuPatch) setFields() bson.M {
+	set := bson.M{}
+	if m.Price != nil {
+		set["price"] = *m.Price
+	}
+	if m.Recommend != nil {
+		set["recommend"] = *m.Recommend
+	}
+	if m.Category != nil {
+		set["category"] = *m.Category
+	}
+	if m.Description != nil {
+		set["description"] = *m.Description
+	}
+	if m.Image != nil {
+		set["image"] = *m.Image
+	}
+	if m.Origins != nil {
+		set["origins"] = *m.Origins
+	}
+	if m.Spiciness != nil {
+		set["spiciness"] = *m.Spiciness
+	}
+	if m.Allergens != nil {
+		set["allergens"] = *m.Allergens
+	}
+	if m.Calories != nil {
+		set["calories"] = *m.Calories
+	}
+	return set
+}
+
+// 신규 메뉴 등록시 입력된 항목 적용
+func (m MenuPatch) Apply(b *BurgerKing) {
+	if m.Price != nil {
+		b.Price = *m.Price
+	}
+	if m.Recommend != nil {
+		b.Recommend = *m.Recommend
+	}
+	if m.Category != nil {
+		b.Category = *m.Category
+	}
+	if m.Description != nil {
+		b.Description = *m.Description
+	}
+	if m.Image != nil {
+		b.Image = *m.Image
+	}
+	if m.Origins != nil {
+		b.Origins = *m.Origins
+	}
+	if m.Spiciness != nil {
+		b.Spiciness = *m.Spiciness
+	}
+	if m.Allergens != nil {
+		b.Allergens = *m.Allergens
+	}
+	if m.Calories != nil {
+		b.Calories = *m.Calories
+	}
+}
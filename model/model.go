@@ -4,41 +4,165 @@ package model
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+var (
+	ErrReviewExists   = errors.New("review already written for this order")
+	ErrReviewNotFound = errors.New("review not found")
+	ErrMenuExists     = errors.New("menu already exists")
+	ErrMenuNotFound   = errors.New("menu not found")
+)
+
+// 삭제되지 않은 메뉴 조건
+var notDeleted = bson.M{"$ne": true}
+
 type Model struct {
-	client       *mongo.Client
-	colMenu      *mongo.Collection
-	colOrderList *mongo.Collection
-	colReview    *mongo.Collection
+	client         *mongo.Client
+	colMenu        *mongo.Collection
+	colOrderList   *mongo.Collection
+	colReview      *mongo.Collection
+	colStore       *mongo.Collection
+	colCustomer    *mongo.Collection
+	colRider       *mongo.Collection
+	colIdempotency *mongo.Collection
+	colPromotion   *mongo.Collection
+	colRedemption  *mongo.Collection
+	colLedger      *mongo.Collection
+	colStampCard   *mongo.Collection
 }
 
+// 주문 상태
+const (
+	StatePendingPayment = "결제대기" //카드 결제 승인 전, 승인되면 접수중 또는 예약접수
+	StateScheduled      = "예약접수" //예약 주문, 예약 시간 전까지 대기
+	StateReceived       = "접수중"
+	StateCooking        = "조리중"
+	StateReady          = "조리완료" //배달원 픽업 또는 서빙 대기
+	StateDelivering     = "배달중"
+	StateDelivered      = "배달완료"
+
+	StateReadyForPickup = "픽업대기" //포장 주문 조리 완료, 고객 수령 대기
+	StatePickedUp       = "픽업완료"
+	StateServed         = "서빙완료" //매장 식사 주문
+
+	StateCancelled = "접수취소" //주문자 취소
+	StateRejected  = "주문거절" //피주문자 거절
+)
+
+// 메뉴 추가시 주문 메뉴 이름 구분자
+const MenuSeparator = " , "
+
 type OrderList struct {
-	Menu       string `bson:"menu"`       //메뉴 이름
-	Pnum       string `bson:"pnum"`       //고객 번호
-	Address    string `bson:"address"`    //고객 주소
-	OrderTime  string `bson:"orderTime"`  //주문 시간
-	State      string `bson:"state"`      //주문 상태
-	ChangeMenu string `bson:"changeMenu"` //주문 추가 및 변경 변수
+	ID              primitive.ObjectID `bson:"_id,omitempty"`             //주문 번호
+	StoreID         primitive.ObjectID `bson:"storeId"`                   //주문 사업장
+	Menu            string             `bson:"menu"`                      //메뉴 이름
+	Pnum            string             `bson:"pnum"`                      //고객 번호
+	Fulfilment      string             `bson:"fulfilment"`                //수령 방법 delivery, pickup, dineIn
+	Address         string             `bson:"address"`                   //고객 주소, 배달 주문만
+	DeliveryAddress *Address           `bson:"deliveryAddress,omitempty"` //주문 당시 배달 주소
+	OrderTime       string             `bson:"orderTime"`                 //주문 시간
+	ScheduledTime   string             `bson:"scheduledTime"`             //예약 시간 (사업장 시간대), 없으면 즉시 주문
+	ReleaseAt       time.Time          `bson:"releaseAt,omitempty"`       //예약 주문 주방 전달 시간
+	State           string             `bson:"state"`                     //주문 상태
+	ChangeMenu      string             `bson:"changeMenu"`                //주문 추가 및 변경 변수
+	Items           []OrderItem        `bson:"items"`                     //주문 메뉴별 선택 옵션 및 가격
+	Subtotal        int                `bson:"subtotal"`                  //메뉴 금액 합계
+	DeliveryZone    string             `bson:"deliveryZone"`              //배달 구역 이름
+	DeliveryFee     int                `bson:"deliveryFee"`               //배달비
+	Coupon          string             `bson:"coupon,omitempty"`          //사용한 쿠폰 코드
+	PromotionID     primitive.ObjectID `bson:"promotionId,omitempty"`     //적용된 프로모션
+	Discount        int                `bson:"discount"`                  //할인 금액 (쿠폰, 스탬프 보상 메뉴)
+	PointsUsed      int                `bson:"pointsUsed"`                //사용한 적립금
+	StampCardID     primitive.ObjectID `bson:"stampCardId,omitempty"`     //보상을 사용한 스탬프 카드
+	Price           int                `bson:"price"`                     //주문 금액 (메뉴 금액 + 배달비 - 할인 - 적립금)
+	Currency        string             `bson:"currency"`                  //통화
+	VATRate         int                `bson:"vatRate"`                   //주문 시점의 부가세율 (%), 가격은 부가세 포함
+	Delivery        *Delivery          `bson:"delivery,omitempty"`        //배차된 배달원 및 배달 진행, 배달 주문만
+	AckedAt         time.Time          `bson:"ackedAt,omitempty"`         //주방 주문 확인 시간, 주문 변경시 초기화
+	Cancellation    *Cancellation      `bson:"cancellation,omitempty"`    //취소 및 거절 정보
+	Refund          *Refund            `bson:"refund,omitempty"`          //취소시 환불 요청
+	Payment         *Payment           `bson:"payment,omitempty"`         //결제 수단 및 결제 상태
 }
 
 type BurgerKing struct {
-	Menu        string `bson:"menu"`        //메뉴이름
-	Price       int    `bson:"price"`       // 가격
-	Recommend   int    `bson:"recommend"`   //추천
-	Grade       int    `bson:"grade"`       //평점
-	ReleaseTime string `bson:"releaseTime"` //출시 시간
+	ID          primitive.ObjectID `bson:"_id,omitempty"` //메뉴 고유 번호
+	StoreID     primitive.ObjectID `bson:"storeId"`       //메뉴 사업장
+	Menu        string             `bson:"menu"`          //메뉴이름
+	Price       int                `bson:"price"`         // 가격, 부가세 포함
+	Currency    string             `bson:"currency"`      //통화 ex. KRW
+	Recommend   int                `bson:"recommend"`     //추천
+	Grade       float64            `bson:"grade"`         //평점 (리뷰 평균)
+	ReviewCount int                `bson:"reviewCount"`   //리뷰 개수
+	ReleaseTime string             `bson:"releaseTime"`   //출시 시간
+
+	Category    string   `bson:"category"`    //카테고리 ex. 버거, 사이드, 음료
+	Description string   `bson:"description"` //메뉴 설명
+	Image       string   `bson:"image"`       //메뉴 이미지 url
+	Origins     []Origin `bson:"origins"`     //재료별 원산지
+	Spiciness   int      `bson:"spiciness"`   //맵기 정도 0~5
+	Allergens   []string `bson:"allergens"`   //알레르기 유발 성분
+	Calories    int      `bson:"calories"`    //열량 kcal
+
+	OptionGroups []OptionGroup `bson:"optionGroups"` //옵션 그룹 ex. 사이즈, 추가 패티, 소스
+	Type         string        `bson:"type"`         //메뉴 종류 single, combo
+	Slots        []ComboSlot   `bson:"slots"`        //세트 메뉴 구성
+
+	Status        string `bson:"status"`        //주문 가능 상태 orderable, soldout
+	DailyStock    int    `bson:"dailyStock"`    //일일 한정 수량, 0이면 제한 없음
+	StockLeft     int    `bson:"stockLeft"`     //금일 남은 수량
+	StockDate     string `bson:"stockDate"`     //남은 수량 기준 날짜
+	AvailableFrom string `bson:"availableFrom"` //주문 가능 시작 시간 HH:MM, 없으면 항상
+	AvailableTo   string `bson:"availableTo"`   //주문 가능 종료 시간 HH:MM
+
+	Deleted   bool   `bson:"deleted"`             //삭제(안보임) 여부
+	DeletedAt string `bson:"deletedAt,omitempty"` //삭제 시간
+	DeletedBy string `bson:"deletedBy,omitempty"` //삭제한 사업장 계정
 }
 
 type MenuReview struct {
-	Menu   string `bson:"menu"`   //메뉴이름
-	Grade  int    `bson:"grade"`  //평점
-	Review string `bson:"review"` //리뷰
+	ID        primitive.ObjectID `bson:"_id,omitempty"`    //리뷰 번호
+	StoreID   primitive.ObjectID `bson:"storeId"`          //리뷰 사업장
+	OrderID   primitive.ObjectID `bson:"orderId"`          //리뷰 대상 주문 번호
+	Pnum      string             `bson:"pnum" json:"-"`    //작성 고객 번호, 공개 응답에서 제외
+	Menu      string             `bson:"menu"`             //메뉴이름
+	Grade     int                `bson:"grade"`            //평점
+	Review    string             `bson:"review"`           //리뷰
+	CreatedAt string             `bson:"createdAt"`        //작성 시간
+	UpdatedAt string             `bson:"updatedAt"`        //수정 시간
+	Images    []ReviewImage      `bson:"images,omitempty"` //첨부 이미지
+
+	Reply       *ReviewReply   `bson:"reply,omitempty"`   //사업장 답글
+	Reports     []ReviewReport `bson:"reports,omitempty"` //신고 내역
+	ReportCount int            `bson:"reportCount"`       //신고 횟수
+	Flagged     bool           `bson:"flagged"`           //검토 필요 여부 (금칙어, 신고 누적)
+	FlagReason  string         `bson:"flagReason"`        //검토 필요 사유
+	Hidden      bool           `bson:"hidden"`            //관리자 숨김 처리 여부
+}
+
+// 리뷰 첨부 이미지, URL은 응답시 설정
+type ReviewImage struct {
+	Key         string `bson:"key"`         //원본 이미지 저장소 key
+	ThumbKey    string `bson:"thumbKey"`    //썸네일 저장소 key
+	ContentType string `bson:"contentType"` //원본 이미지 타입
+	URL         string `bson:"-"`
+	ThumbURL    string `bson:"-"`
+}
+
+// 메뉴별 리뷰 통계
+type ReviewStats struct {
+	Average   float64     //평균 평점
+	Count     int         //리뷰 개수
+	Histogram map[int]int //평점(1~5)별 리뷰 개수
 }
 
 // mongodb connect
@@ -57,17 +181,106 @@ func NewModel() (*Model, error) {
 		r.colMenu = db.Collection("menu-list")
 		r.colOrderList = db.Collection("order-info")
 		r.colReview = db.Collection("menu-review")
+		r.colStore = db.Collection("store")
+		r.colCustomer = db.Collection("customer")
+		r.colRider = db.Collection("rider")
+		r.colIdempotency = db.Collection("idempotency-key")
+		r.colPromotion = db.Collection("promotion")
+		r.colRedemption = db.Collection("promotion-redemption")
+		r.colLedger = db.Collection("loyalty-ledger")
+		r.colStampCard = db.Collection("stamp-card")
+
+		// 주문 한 건의 메뉴당 리뷰는 하나만 작성 가능 (주문 번호 없는 이전 리뷰는 제외)
+		reviewIndex := mongo.IndexModel{
+			Keys: bson.D{{Key: "orderId", Value: 1}, {Key: "menu", Value: 1}},
+			Options: options.Index().SetUnique(true).
+				SetPartialFilterExpression(bson.M{"orderId": bson.M{"$exists": true}}),
+		}
+		if _, err := r.colReview.Indexes().CreateOne(context.Background(), reviewIndex); err != nil {
+			return nil, err
+		}
+		// 고객 번호당 고객 정보 하나
+		customerIndex := mongo.IndexModel{
+			Keys:    bson.D{{Key: "pnum", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		}
+		if _, err := r.colCustomer.Indexes().CreateOne(context.Background(), customerIndex); err != nil {
+			return nil, err
+		}
+		// 전달 시간이 된 예약 주문 조회
+		scheduleIndex := mongo.IndexModel{
+			Keys:    bson.D{{Key: "state", Value: 1}, {Key: "releaseAt", Value: 1}},
+			Options: options.Index().SetPartialFilterExpression(bson.M{"releaseAt": bson.M{"$exists": true}}),
+		}
+		if _, err := r.colOrderList.Indexes().CreateOne(context.Background(), scheduleIndex); err != nil {
+			return nil, err
+		}
+		// 배달원 계정당 배달원 정보 하나
+		riderIndex := mongo.IndexModel{
+			Keys:    bson.D{{Key: "account", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		}
+		if _, err := r.colRider.Indexes().CreateOne(context.Background(), riderIndex); err != nil {
+			return nil, err
+		}
+		// 만료 시간이 지난 Idempotency-Key 자동 삭제
+		idempotencyIndex := mongo.IndexModel{
+			Keys:    bson.D{{Key: "expireAt", Value: 1}},
+			Options: options.Index().SetExpireAfterSeconds(0),
+		}
+		if _, err := r.colIdempotency.Indexes().CreateOne(context.Background(), idempotencyIndex); err != nil {
+			return nil, err
+		}
+		// 사업장 내 쿠폰 코드 중복 불가
+		promotionIndex := mongo.IndexModel{
+			Keys:    bson.D{{Key: "storeId", Value: 1}, {Key: "code", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		}
+		if _, err := r.colPromotion.Indexes().CreateOne(context.Background(), promotionIndex); err != nil {
+			return nil, err
+		}
+		// 프로모션별 고객 사용 기록 하나, 고객당 사용 횟수 제한에 사용
+		redemptionIndex := mongo.IndexModel{
+			Keys:    bson.D{{Key: "promotionId", Value: 1}, {Key: "pnum", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		}
+		if _, err := r.colRedemption.Indexes().CreateOne(context.Background(), redemptionIndex); err != nil {
+			return nil, err
+		}
+		// 고객별 원장 순번 중복 불가, 같은 잔액으로 동시에 기록하면 하나만 성공
+		ledgerIndex := mongo.IndexModel{
+			Keys:    bson.D{{Key: "pnum", Value: 1}, {Key: "seq", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		}
+		if _, err := r.colLedger.Indexes().CreateOne(context.Background(), ledgerIndex); err != nil {
+			return nil, err
+		}
 	}
 	return r, nil
 }
 
-// 전체 메뉴 정렬 후 조회(주문자)
+// 주문에 포함된 메뉴 이름 목록 (주문 메뉴 정보가 없는 이전 주문은 구분자로 나눔)
+func (o OrderList) MenuNames() []string {
+	if len(o.Items) > 0 {
+		names := []string{}
+		for _, item := range o.Items {
+			names = append(names, item.Menu)
+			for _, comp := range item.Components { //세트 구성 메뉴 포함
+				names = append(names, comp.Menu)
+			}
+		}
+		return names
+	}
+	return strings.Split(o.Menu, MenuSeparator)
+}
+
+// 사업장 전체 메뉴 정렬 후 조회(주문자)
 
-func (p *Model) GetAllMenu(sortOption string) []BurgerKing {
+func (p *Model) GetAllMenu(storeID primitive.ObjectID, sortOption string) []BurgerKing {
 
-	filter := bson.D{}
+	filter := bson.M{"storeId": storeID, "deleted": notDeleted} //삭제된 메뉴는 제외
 	//높은 순으로 정렬 (평점 많은순, 최신순, 가격순)
-	opts := options.Find().SetSort(bson.D{{sortOption, -1}})
+	opts := options.Find().SetSort(bson.D{{Key: sortOption, Value: -1}})
 	cursor, err := p.colMenu.Find(context.TODO(), filter, opts)
 	var burgers []BurgerKing
 	if err = cursor.All(context.TODO(), &burgers); err != nil {
@@ -88,12 +301,12 @@ func (p *Model) GetAllMenu(sortOption string) []BurgerKing {
 }
 
 // 메뉴이름으로 주문내역
-func (p *Model) GetOrderListByMenu(flag, menuName string) (OrderList, error) {
+func (p *Model) GetOrderListByMenu(storeID primitive.ObjectID, flag, menuName string) (OrderList, error) {
 	opts := []*options.FindOneOptions{}
 
 	var filter bson.M
 	if flag == "menu" {
-		filter = bson.M{"menu": menuName}
+		filter = bson.M{"storeId": storeID, "menu": menuName, "state": bson.M{"$nin": bson.A{StateCancelled, StateRejected}}} //취소된 주문 제외
 	}
 
 	var orderInfo OrderList
@@ -104,10 +317,28 @@ func (p *Model) GetOrderListByMenu(flag, menuName string) (OrderList, error) {
 	}
 }
 
-func (p *Model) GetAllOrderList() []OrderList {
-	filter := bson.D{}
+// 주문 번호로 주문내역 조회
+func (p *Model) GetOrder(orderID primitive.ObjectID) (OrderList, error) {
+	filter := bson.M{"_id": orderID}
+
+	var orderInfo OrderList
+	if err := p.colOrderList.FindOne(context.TODO(), filter).Decode(&orderInfo); err != nil {
+		return orderInfo, err
+	}
+	return orderInfo, nil
+}
+
+// 주문내역 최신순 조회, 사업장 번호와 고객 번호가 있으면 해당 주문만
+func (p *Model) GetAllOrderList(storeID primitive.ObjectID, pnum string) []OrderList {
+	filter := bson.M{}
+	if !storeID.IsZero() {
+		filter["storeId"] = storeID
+	}
+	if len(pnum) > 0 {
+		filter["pnum"] = pnum
+	}
 	//높은 순으로 정렬 (평점 많은순, 최신순, 가격순)
-	opts := options.Find().SetSort(bson.D{{"orderTime", -1}})
+	opts := options.Find().SetSort(bson.D{{Key: "orderTime", Value: -1}})
 	cursor, err := p.colOrderList.Find(context.TODO(), filter, opts)
 	var orders []OrderList
 	if err = cursor.All(context.TODO(), &orders); err != nil {
@@ -128,44 +359,221 @@ func (p *Model) GetAllOrderList() []OrderList {
 }
 
 // 해당 메뉴에 대한 리뷰 및 평점 보기 (주문자)
-func (p *Model) GetReview(menuName string) MenuReview {
+func (p *Model) GetReview(storeID primitive.ObjectID, menuName string) (MenuReview, error) {
 	opts := []*options.FindOneOptions{}
 
-	filter := bson.M{"menu": menuName}
+	filter := bson.M{"storeId": storeID, "menu": menuName, "hidden": bson.M{"$ne": true}}
 
 	var review MenuReview
 	if err := p.colReview.FindOne(context.TODO(), filter, opts...).Decode(&review); err != nil {
-		panic(err)
+		return review, err
+	}
+	return review, nil
+}
+
+// 해당 메뉴의 전체 리뷰를 최신순으로 페이지 단위 조회 (주문자)
+func (p *Model) GetReviewList(storeID primitive.ObjectID, menuName string, page, size int) ([]MenuReview, error) {
+	filter := bson.M{"storeId": storeID, "menu": menuName, "hidden": bson.M{"$ne": true}}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "createdAt", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64((page - 1) * size)).
+		SetLimit(int64(size))
+
+	cursor, err := p.colReview.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	reviews := []MenuReview{}
+	if err := cursor.All(context.TODO(), &reviews); err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// 해당 메뉴의 평균 평점, 리뷰 개수, 평점별 분포 조회 (주문자)
+func (p *Model) GetReviewStats(storeID primitive.ObjectID, menuName string) (ReviewStats, error) {
+	stats := ReviewStats{Histogram: map[int]int{1: 0, 2: 0, 3: 0, 4: 0, 5: 0}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"storeId": storeID, "menu": menuName, "hidden": bson.M{"$ne": true}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$grade",
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+	cursor, err := p.colReview.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return stats, err
+	}
+	var groups []struct {
+		Grade int `bson:"_id"`
+		Count int `bson:"count"`
+	}
+	if err := cursor.All(context.TODO(), &groups); err != nil {
+		return stats, err
+	}
+
+	sum := 0
+	for _, g := range groups {
+		stats.Histogram[g.Grade] += g.Count
+		stats.Count += g.Count
+		sum += g.Grade * g.Count
+	}
+	if stats.Count > 0 {
+		stats.Average = math.Round(float64(sum)/float64(stats.Count)*10) / 10
 	}
-	return review
+	return stats, nil
 }
 
-// 메뉴 주문
-func (p *Model) OrderMenu(orderInfo OrderList) error {
-	if _, err := p.colOrderList.InsertOne(context.TODO(), orderInfo); err != nil {
+// 메뉴 주문, 생성된 주문 번호 반환
+func (p *Model) OrderMenu(orderInfo OrderList) (primitive.ObjectID, error) {
+	res, err := p.colOrderList.InsertOne(context.TODO(), orderInfo)
+	if err != nil {
 		fmt.Println("Your order failed")
-		return fmt.Errorf(" Your order failed")
+		return primitive.NilObjectID, fmt.Errorf(" Your order failed")
 	}
 	fmt.Println("Order Success")
-	return nil
+	return res.InsertedID.(primitive.ObjectID), nil
+}
+
+// 리뷰 번호로 리뷰 조회
+func (p *Model) GetReviewByID(reviewID primitive.ObjectID) (MenuReview, error) {
+	filter := bson.M{"_id": reviewID}
+
+	var review MenuReview
+	if err := p.colReview.FindOne(context.TODO(), filter).Decode(&review); err != nil {
+		return review, err
+	}
+	return review, nil
+}
+
+// 해당 주문의 메뉴에 작성된 리뷰가 있는지 확인
+func (p *Model) HasReview(orderID primitive.ObjectID, menuName string) (bool, error) {
+	filter := bson.M{"orderId": orderID, "menu": menuName}
+	count, err := p.colReview.CountDocuments(context.TODO(), filter)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
 }
 
 // 해당 메뉴의 리뷰 및 평점 작성
 func (p *Model) WriteReview(review MenuReview) error {
 	if _, err := p.colReview.InsertOne(context.TODO(), review); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrReviewExists
+		}
 		fmt.Println("Failed to wirte review")
 		return fmt.Errorf(" Failed to wirte review")
 	}
+	return p.RefreshMenuGrade(review.StoreID, review.Menu)
+}
+
+// 본인이 작성한 리뷰의 평점 및 내용 수정, 금칙어 검사 결과(flagReason)가 있으면 검토 대상으로 표시
+func (p *Model) UpdateReview(reviewID primitive.ObjectID, pnum string, grade int, text, updatedAt, flagReason string) error {
+	filter := bson.M{"_id": reviewID, "pnum": pnum}
+	set := bson.M{
+		"grade":     grade,
+		"review":    text,
+		"updatedAt": updatedAt,
+	}
+	if len(flagReason) > 0 {
+		set["flagged"] = true
+		set["flagReason"] = flagReason
+	}
+	update := bson.M{"$set": set}
+
+	var review MenuReview
+	if err := p.colReview.FindOneAndUpdate(context.TODO(), filter, update).Decode(&review); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return ErrReviewNotFound
+		}
+		return err
+	}
+	return p.RefreshMenuGrade(review.StoreID, review.Menu)
+}
+
+// 본인이 작성한 리뷰 삭제, 삭제된 리뷰 반환
+func (p *Model) DeleteReview(reviewID primitive.ObjectID, pnum string) (MenuReview, error) {
+	filter := bson.M{"_id": reviewID, "pnum": pnum}
+
+	var review MenuReview
+	if err := p.colReview.FindOneAndDelete(context.TODO(), filter).Decode(&review); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return review, ErrReviewNotFound
+		}
+		return review, err
+	}
+	return review, p.RefreshMenuGrade(review.StoreID, review.Menu)
+}
+
+// 리뷰 데이터로 메뉴의 평균 평점 및 리뷰 개수 갱신
+func (p *Model) RefreshMenuGrade(storeID primitive.ObjectID, menuName string) error {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"storeId": storeID, "menu": menuName, "hidden": bson.M{"$ne": true}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$menu",
+			"grade": bson.M{"$avg": "$grade"},
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+	cursor, err := p.colReview.Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		return err
+	}
+	var stats []struct {
+		Grade float64 `bson:"grade"`
+		Count int     `bson:"count"`
+	}
+	if err := cursor.All(context.TODO(), &stats); err != nil {
+		return err
+	}
+
+	grade, count := 0.0, 0 //리뷰가 없으면 0점
+	if len(stats) > 0 {
+		grade = math.Round(stats[0].Grade*10) / 10 //소수점 첫째자리까지
+		count = stats[0].Count
+	}
+
+	filter := bson.M{"storeId": storeID, "menu": menuName, "deleted": notDeleted}
+	update := bson.M{
+		"$set": bson.M{
+			"grade":       grade,
+			"reviewCount": count,
+		},
+	}
+	if _, err := p.colMenu.UpdateOne(context.TODO(), filter, update); err != nil {
+		return err
+	}
 	return nil
 }
 
-// 메뉴 업데이트 (주문자)
-func (p *Model) ChangeMenu(menu, afterMenu string) error {
-	filter := bson.M{"menu": menu}
+// 메뉴 업데이트 (주문자), 주문 메뉴 전체를 변경
+func (p *Model) ChangeMenu(orderID primitive.ObjectID, afterMenu string, items []OrderItem, subtotal, price int) error {
+	filter := bson.M{"_id": orderID}
 	update := bson.M{
 		"$set": bson.M{
-			"menu": afterMenu,
+			"menu":     afterMenu,
+			"items":    items,
+			"subtotal": subtotal,
+			"price":    price,
 		},
+		"$unset": bson.M{"ackedAt": ""}, //주방에서 다시 확인
+	}
+	if _, err := p.colOrderList.UpdateOne(context.Background(), filter, update); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 메뉴 추가 (주문자), 주문 메뉴 이름은 구분자로 연결
+func (p *Model) AddOrderItem(orderID primitive.ObjectID, menu string, item OrderItem) error {
+	filter := bson.M{"_id": orderID}
+	update := bson.M{
+		"$set":   bson.M{"menu": menu},
+		"$push":  bson.M{"items": item},
+		"$inc":   bson.M{"subtotal": item.Price, "price": item.Price},
+		"$unset": bson.M{"ackedAt": ""}, //주방에서 다시 확인
 	}
 	if _, err := p.colOrderList.UpdateOne(context.Background(), filter, update); err != nil {
 		return err
@@ -176,12 +584,12 @@ func (p *Model) ChangeMenu(menu, afterMenu string) error {
 //-----------------피주문자--------------------//
 
 // 메뉴이름으로 조회 후 메뉴 정보 반환(피주문자)
-func (p *Model) GetMenu(flag, menuName string) (BurgerKing, error) {
+func (p *Model) GetMenu(storeID primitive.ObjectID, flag, menuName string) (BurgerKing, error) {
 	opts := []*options.FindOneOptions{}
 
 	var filter bson.M
 	if flag == "menu" {
-		filter = bson.M{"menu": menuName}
+		filter = bson.M{"storeId": storeID, "menu": menuName, "deleted": notDeleted}
 	}
 
 	var burger BurgerKing
@@ -202,47 +610,123 @@ func (p *Model) CreateMenu(burger BurgerKing) error {
 	return nil
 }
 
-// 메뉴 삭제 (피주문자)
-func (p *Model) DeleteMenu(menuName string) error {
-	filter := bson.M{"menu": menuName}
+// 메뉴 삭제 (피주문자), 지난 주문 및 리뷰를 위해 삭제 표시만 하고 데이터는 유지
+func (p *Model) DeleteMenu(storeID primitive.ObjectID, menuName, deletedBy, deletedAt string) error {
+	filter := bson.M{"storeId": storeID, "menu": menuName, "deleted": notDeleted}
+	update := bson.M{
+		"$set": bson.M{
+			"deleted":   true,
+			"deletedAt": deletedAt,
+			"deletedBy": deletedBy,
+		},
+	}
 
-	if res, err := p.colMenu.DeleteOne(context.TODO(), filter); res.DeletedCount <= 0 {
+	if res, err := p.colMenu.UpdateOne(context.TODO(), filter, update); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
 		return fmt.Errorf("Could not Delete, There is no  %s in menu", menuName)
+	}
+
+	return nil
+}
+
+// 삭제된 메뉴 최신 삭제순 조회 (피주문자)
+func (p *Model) GetDeletedMenu(storeID primitive.ObjectID) ([]BurgerKing, error) {
+	filter := bson.M{"storeId": storeID, "deleted": true}
+	opts := options.Find().SetSort(bson.D{{Key: "deletedAt", Value: -1}})
+
+	cursor, err := p.colMenu.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	burgers := []BurgerKing{}
+	if err := cursor.All(context.TODO(), &burgers); err != nil {
+		return nil, err
+	}
+	return burgers, nil
+}
+
+// 삭제된 메뉴 복구 (피주문자), 같은 이름의 메뉴가 있으면 복구 불가
+func (p *Model) RestoreMenu(storeID primitive.ObjectID, menuName string) error {
+	if _, err := p.GetMenu(storeID, "menu", menuName); err == nil {
+		return ErrMenuExists
+	} else if err != mongo.ErrNoDocuments {
+		return err
+	}
+
+	filter := bson.M{"storeId": storeID, "menu": menuName, "deleted": true}
+	update := bson.M{
+		"$set":   bson.M{"deleted": false},
+		"$unset": bson.M{"deletedAt": "", "deletedBy": ""},
+	}
+	opts := options.FindOneAndUpdate().SetSort(bson.D{{Key: "deletedAt", Value: -1}}) //가장 최근 삭제된 메뉴
+
+	if err := p.colMenu.FindOneAndUpdate(context.TODO(), filter, update, opts).Err(); err == mongo.ErrNoDocuments {
+		return ErrMenuNotFound
 	} else if err != nil {
 		return err
 	}
+	return p.RefreshMenuGrade(storeID, menuName) //삭제 기간 중 변경된 리뷰 반영
+}
 
-	return nil
+// 삭제된 메뉴 영구 삭제 (관리자)
+func (p *Model) PurgeMenu(storeID primitive.ObjectID, menuName string) (int64, error) {
+	filter := bson.M{"storeId": storeID, "menu": menuName, "deleted": true}
+
+	res, err := p.colMenu.DeleteMany(context.TODO(), filter)
+	if err != nil {
+		return 0, err
+	} else if res.DeletedCount <= 0 {
+		return 0, ErrMenuNotFound
+	}
+	return res.DeletedCount, nil
+}
+
+// 메뉴 고유 번호로 조회
+func (p *Model) GetMenuByID(menuID primitive.ObjectID) (BurgerKing, error) {
+	filter := bson.M{"_id": menuID, "deleted": notDeleted}
+
+	var burger BurgerKing
+	if err := p.colMenu.FindOne(context.TODO(), filter).Decode(&burger); err != nil {
+		return burger, err
+	}
+	return burger, nil
 }
 
-// 메뉴 업데이트 (피주문자)
-func (p *Model) UpdateMenu(menuName string, price, recommend int) error {
+// 메뉴 업데이트 (피주문자), 입력된 항목만 변경
+func (p *Model) UpdateMenu(storeID, menuID primitive.ObjectID, patch MenuPatch) error {
 
-	filter := bson.M{"menu": menuName}
+	filter := bson.M{"_id": menuID, "storeId": storeID, "deleted": notDeleted}
+	set := patch.setFields()
+	if len(set) <= 0 {
+		return nil
+	}
 	update := bson.M{
-		"$set": bson.M{
-			"price":     price,
-			"recommend": recommend,
-		},
+		"$set": set,
 	}
 
-	if _, err := p.colMenu.UpdateOne(context.Background(), filter, update); err != nil {
+	if res, err := p.colMenu.UpdateOne(context.Background(), filter, update); err != nil {
 		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrMenuNotFound
 	}
 
 	return nil
 }
 
-// 주문 상태 업데이트(피주문자)
-func (p *Model) UpdateState(menuName, state string) error {
-	filter := bson.M{"menu": menuName}
+// 주문 번호로 주문 상태 업데이트(피주문자)
+func (p *Model) UpdateStateByID(storeID, orderID primitive.ObjectID, from, state string) error {
+	filter := bson.M{"_id": orderID, "storeId": storeID, "state": from} //확인한 상태에서만 변경
 	update := bson.M{
 		"$set": bson.M{
 			"state": state,
 		},
 	}
-	if _, err := p.colOrderList.UpdateOne(context.Background(), filter, update); err != nil {
+	res, err := p.colOrderList.UpdateOne(context.Background(), filter, update)
+	if err != nil {
 		return err
+	} else if res.MatchedCount == 0 {
+		return fmt.Errorf("There is no order %s in state %s", orderID.Hex(), from)
 	}
 	return nil
 }
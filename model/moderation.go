@@ -0,0 +1,120 @@
+package model
+
+//moderation.go : 리뷰 답글, 신고, 숨김 처리 등 리뷰 관리 데이터 핸들링
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 리뷰에 대한 사업장 답글
+type ReviewReply struct {
+	Seller    string `bson:"seller"`    //답글 작성자
+	Reply     string `bson:"reply"`     //답글 내용
+	CreatedAt string `bson:"createdAt"` //작성 시간
+}
+
+// 리뷰 신고 내역
+type ReviewReport struct {
+	Reporter  string `bson:"reporter" json:"-"` //신고자 (고객 번호 또는 사업장), 공개 응답에서 제외
+	Role      string `bson:"role"`              //신고자 구분 customer, seller
+	Reason    string `bson:"reason"`            //신고 사유
+	CreatedAt string `bson:"createdAt"`         //신고 시간
+}
+
+// 리뷰에 사업장 답글 작성 및 수정 (피주문자)
+func (p *Model) ReplyReview(storeID, reviewID primitive.ObjectID, reply ReviewReply) error {
+	filter := bson.M{"_id": reviewID, "storeId": storeID} //본인 사업장 리뷰만
+	update := bson.M{
+		"$set": bson.M{
+			"reply": reply,
+		},
+	}
+	res, err := p.colReview.UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return err
+	} else if res.MatchedCount == 0 {
+		return ErrReviewNotFound
+	}
+	return nil
+}
+
+// 리뷰 신고, 신고 횟수가 threshold 이상이면 검토 대상으로 표시
+func (p *Model) ReportReview(reviewID primitive.ObjectID, report ReviewReport, threshold int) (MenuReview, error) {
+	filter := bson.M{"_id": reviewID, "reports.reporter": bson.M{"$ne": report.Reporter}} //같은 신고자는 한번만
+	update := bson.M{
+		"$push": bson.M{"reports": report},
+		"$inc":  bson.M{"reportCount": 1},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var review MenuReview
+	if err := p.colReview.FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&review); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return review, ErrReviewNotFound
+		}
+		return review, err
+	}
+
+	if threshold > 0 && review.ReportCount >= threshold && !review.Flagged {
+		if err := p.FlagReview(reviewID, "reported"); err != nil {
+			return review, err
+		}
+		review.Flagged, review.FlagReason = true, "reported"
+	}
+	return review, nil
+}
+
+// 리뷰를 검토 대상으로 표시
+func (p *Model) FlagReview(reviewID primitive.ObjectID, reason string) error {
+	filter := bson.M{"_id": reviewID}
+	update := bson.M{
+		"$set": bson.M{
+			"flagged":    true,
+			"flagReason": reason,
+		},
+	}
+	if _, err := p.colReview.UpdateOne(context.TODO(), filter, update); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 검토 대상 리뷰 목록 최신순 조회 (관리자)
+func (p *Model) GetFlaggedReviews() ([]MenuReview, error) {
+	filter := bson.M{"flagged": true}
+	opts := options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}})
+
+	cursor, err := p.colReview.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	reviews := []MenuReview{}
+	if err := cursor.All(context.TODO(), &reviews); err != nil {
+		return nil, err
+	}
+	return reviews, nil
+}
+
+// 리뷰 숨김 및 숨김 해제, 검토 완료 처리 후 메뉴 평점 갱신 (관리자)
+func (p *Model) SetReviewHidden(reviewID primitive.ObjectID, hidden bool) error {
+	filter := bson.M{"_id": reviewID}
+	update := bson.M{
+		"$set": bson.M{
+			"hidden":  hidden,
+			"flagged": false,
+		},
+	}
+
+	var review MenuReview
+	if err := p.colReview.FindOneAndUpdate(context.TODO(), filter, update).Decode(&review); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return ErrReviewNotFound
+		}
+		return err
+	}
+	return p.RefreshMenuGrade(review.StoreID, review.Menu)
+}
@@ -0,0 +1,125 @@
+package model
+
+//option.go : 메뉴 옵션 그룹(사이즈, 추가 패티, 소스 선택 등) 및 주문 메뉴 가격 계산
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 메뉴 옵션 그룹, 그룹별 최소/최대 선택 개수
+type OptionGroup struct {
+	Name    string   `bson:"name"`    //옵션 그룹 이름 ex. 사이즈
+	Min     int      `bson:"min"`     //최소 선택 개수, 1 이상이면 필수 선택
+	Max     int      `bson:"max"`     //최대 선택 개수
+	Options []Option `bson:"options"` //선택 가능한 옵션
+}
+
+type Option struct {
+	Name       string `bson:"name"`       //옵션 이름 ex. 라지
+	PriceDelta int    `bson:"priceDelta"` //추가 금액, 할인은 음수
+}
+
+// 주문시 선택한 옵션
+type SelectedOption struct {
+	Group      string `bson:"group"`      //옵션 그룹 이름
+	Name       string `bson:"name"`       //옵션 이름
+	PriceDelta int    `bson:"priceDelta"` //주문 시점의 추가 금액
+}
+
+// 주문 메뉴 한 줄, 주문 시점의 가격으로 저장
+type OrderItem struct {
+	Menu      string           `bson:"menu"`      //메뉴이름
+	Options   []SelectedOption `bson:"options"`   //선택 옵션
+	BasePrice int              `bson:"basePrice"` //메뉴 기본 가격
+	Price     int              `bson:"price"`     //옵션, 세트 추가 금액 포함 가격
+
+	Components []OrderComponent `bson:"components,omitempty"` //세트 구성 메뉴
+}
+
+// 옵션 그룹 정의 확인
+func ValidateOptionGroups(groups []OptionGroup) error {
+	names := map[string]bool{}
+	for _, g := range groups {
+		if len(g.Name) <= 0 || names[g.Name] {
+			return fmt.Errorf("option group name must be unique and not empty, %q", g.Name)
+		}
+		names[g.Name] = true
+		if g.Min < 0 || g.Max < 1 || g.Min > g.Max || g.Max > len(g.Options) {
+			return fmt.Errorf("%s : min/max must be 0 <= min <= max <= number of options", g.Name)
+		}
+
+		options := map[string]bool{}
+		for _, o := range g.Options {
+			if len(o.Name) <= 0 || options[o.Name] {
+				return fmt.Errorf("%s : option name must be unique and not empty, %q", g.Name, o.Name)
+			}
+			options[o.Name] = true
+		}
+	}
+	return nil
+}
+
+// 선택한 옵션을 메뉴의 옵션 그룹 정의로 검증 후 가격이 포함된 주문 메뉴 생성
+func (b BurgerKing) NewOrderItem(selected []SelectedOption) (OrderItem, error) {
+	item := OrderItem{Menu: b.Menu, Options: []SelectedOption{}, BasePrice: b.Price, Price: b.Price}
+
+	counts := map[string]int{}
+	picked := map[string]bool{}
+	for _, s := range selected {
+		option, ok := b.findOption(s.Group, s.Name)
+		if !ok {
+			return item, fmt.Errorf("%s has no option %s:%s", b.Menu, s.Group, s.Name)
+		}
+		if key := s.Group + ":" + s.Name; picked[key] {
+			return item, fmt.Errorf("option %s is selected twice", key)
+		} else {
+			picked[key] = true
+		}
+		counts[s.Group]++
+		item.Options = append(item.Options, SelectedOption{Group: s.Group, Name: s.Name, PriceDelta: option.PriceDelta})
+		item.Price += option.PriceDelta
+	}
+
+	for _, g := range b.OptionGroups {
+		if counts[g.Name] < g.Min || counts[g.Name] > g.Max {
+			return item, fmt.Errorf("%s : select %d ~ %d options", g.Name, g.Min, g.Max)
+		}
+	}
+	if item.Price < 0 {
+		item.Price = 0
+	}
+	return item, nil
+}
+
+func (b BurgerKing) findOption(group, name string) (Option, bool) {
+	for _, g := range b.OptionGroups {
+		if g.Name != group {
+			continue
+		}
+		for _, o := range g.Options {
+			if o.Name == name {
+				return o, true
+			}
+		}
+	}
+	return Option{}, false
+}
+
+// 메뉴 옵션 그룹 설정 (피주문자)
+func (p *Model) SetOptionGroups(menuID primitive.ObjectID, groups []OptionGroup) error {
+	filter := bson.M{"_id": menuID, "deleted": notDeleted}
+	update := bson.M{
+		"$set": bson.M{
+			"optionGroups": groups,
+		},
+	}
+	if res, err := p.colMenu.UpdateOne(context.TODO(), filter, update); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrMenuNotFound
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package model
+
+//payment.go : 주문 결제 수단, 결제 승인, 매입 및 환불 처리 상태 데이터 핸들링
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// 결제 수단
+const (
+	PayCard = "card" //결제 대행사 카드 결제, 승인 후 접수
+	PayCash = "cash" //만나서 결제, 즉시 접수
+)
+
+// 결제 상태
+const (
+	PaymentPending    = "pending"    //승인 대기 (카드), 수령시 결제 대기 (현금)
+	PaymentAuthorized = "authorized" //카드 승인, 금액 홀드
+	PaymentCaptured   = "captured"   //매입 완료 (현금은 수령 완료)
+	PaymentVoided     = "voided"     //매입 전 취소
+	PaymentRefunded   = "refunded"   //매입 후 환불
+	PaymentFailed     = "failed"     //승인 거절, 다시 시도 가능
+)
+
+// 주문 결제 정보
+type Payment struct {
+	Method        string    `bson:"method"`                 //card, cash
+	Status        string    `bson:"status"`                 //결제 상태
+	Amount        int       `bson:"amount"`                 //결제 금액
+	Gateway       string    `bson:"gateway"`                //결제 대행사
+	IntentID      string    `bson:"intentId"`               //결제 대행사 결제 요청 번호
+	FailureReason string    `bson:"failureReason"`          //승인 거절 사유
+	AuthorizedAt  time.Time `bson:"authorizedAt,omitempty"` //승인 시간
+	CapturedAt    time.Time `bson:"capturedAt,omitempty"`   //매입 시간
+}
+
+// 결제 수단 확인
+func ValidPaymentMethod(method string) bool {
+	return method == PayCard || method == PayCash
+}
+
+// 카드 결제 주문인지 확인
+func (o OrderList) PaidByCard() bool {
+	return o.Payment != nil && o.Payment.Method == PayCard
+}
+
+// 결제 승인 후 주문 접수, 결제 대기 주문만 가능
+func (p *Model) AuthorizePayment(orderID primitive.ObjectID, state string, t time.Time) error {
+	filter := bson.M{"_id": orderID, "state": StatePendingPayment}
+	update := bson.M{"$set": bson.M{"state": state, "payment.status": PaymentAuthorized, "payment.authorizedAt": t, "payment.failureReason": ""}}
+	if res, err := p.colOrderList.UpdateOne(context.TODO(), filter, update); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return fmt.Errorf("There is no order %s in state %s", orderID.Hex(), StatePendingPayment)
+	}
+	return nil
+}
+
+// 결제 승인 거절 기록, 주문은 결제 대기 유지
+func (p *Model) FailPayment(orderID primitive.ObjectID, reason string) error {
+	update := bson.M{"$set": bson.M{"payment.status": PaymentFailed, "payment.failureReason": reason}}
+	_, err := p.colOrderList.UpdateOne(context.TODO(), bson.M{"_id": orderID}, update)
+	return err
+}
+
+// 결제 대행사 처리 실패 사유 기록, 결제 상태는 유지해 재시도 가능
+func (p *Model) SetPaymentFailure(orderID primitive.ObjectID, reason string) error {
+	_, err := p.colOrderList.UpdateOne(context.TODO(), bson.M{"_id": orderID}, bson.M{"$set": bson.M{"payment.failureReason": reason}})
+	return err
+}
+
+// 주문 시간이 before 이전인 결제 대기 주문 조회, 승인 대기 시간 초과 취소에 사용
+func (p *Model) GetExpiredPendingPayments(before time.Time) ([]OrderList, error) {
+	filter := bson.M{"state": StatePendingPayment, "orderTime": bson.M{"$lte": before.In(time.Local).Format("2006-01-02 15:04:05")}}
+	cursor, err := p.colOrderList.Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+	orders := []OrderList{}
+	if err := cursor.All(context.TODO(), &orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
+
+// 결제 매입 기록, 승인된 카드 결제 또는 현금 결제만. 현금은 메뉴 변경이 반영된 수령 금액
+func (p *Model) CapturePayment(orderID primitive.ObjectID, amount int, t time.Time) error {
+	filter := bson.M{"_id": orderID, "payment.status": bson.M{"$in": bson.A{PaymentAuthorized, PaymentPending}}}
+	update := bson.M{"$set": bson.M{"payment.status": PaymentCaptured, "payment.amount": amount, "payment.capturedAt": t}}
+	_, err := p.colOrderList.UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+// 결제 상태 변경
+func (p *Model) SetPaymentStatus(orderID primitive.ObjectID, status string) error {
+	_, err := p.colOrderList.UpdateOne(context.TODO(), bson.M{"_id": orderID}, bson.M{"$set": bson.M{"payment.status": status}})
+	return err
+}
+
+// 환불 처리 결과 기록
+func (p *Model) CompleteRefund(orderID primitive.ObjectID, status, refundID string, t time.Time) error {
+	update := bson.M{"$set": bson.M{"refund.status": status, "refund.refundId": refundID, "refund.completedAt": t}}
+	_, err := p.colOrderList.UpdateOne(context.TODO(), bson.M{"_id": orderID}, update)
+	return err
+}
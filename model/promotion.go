@@ -0,0 +1,245 @@
+package model
+
+//promotion.go : 쿠폰 및 프로모션 정보, 할인 금액 계산, 사용 횟수 제한 데이터 핸들링
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 프로모션 종류
+const (
+	PromoPercent      = "percent"      //메뉴 금액 비율 할인
+	PromoFixed        = "fixed"        //정액 할인
+	PromoFreeDelivery = "freeDelivery" //배달비 무료
+	PromoBuyXGetY     = "buyXGetY"     //메뉴 X개 구매시 메뉴 Y개 증정
+)
+
+var (
+	ErrPromotionNotFound         = errors.New("coupon not found")
+	ErrPromotionExpired          = errors.New("coupon is not valid now")
+	ErrPromotionNotApplicable    = errors.New("coupon does not apply to this order")
+	ErrPromotionExhausted        = errors.New("coupon usage limit reached")
+	ErrPromotionCustomerLimit    = errors.New("you already used this coupon")
+	ErrPromotionCustomerRequired = errors.New("pnum is required to use a coupon")
+)
+
+type Promotion struct {
+	ID               primitive.ObjectID `bson:"_id,omitempty"`    //프로모션 고유 번호
+	StoreID          primitive.ObjectID `bson:"storeId"`          //사업장
+	Code             string             `bson:"code"`             //쿠폰 코드, 사업장 내 중복 불가
+	Name             string             `bson:"name"`             //프로모션 이름
+	Type             string             `bson:"type"`             //percent, fixed, freeDelivery, buyXGetY
+	Value            int                `bson:"value"`            //할인율(%) 또는 할인 금액
+	MaxDiscount      int                `bson:"maxDiscount"`      //비율 할인 최대 금액, 0 : 제한 없음
+	BuyMenu          string             `bson:"buyMenu"`          //구매 메뉴, buyXGetY
+	BuyQty           int                `bson:"buyQty"`           //구매 수량, buyXGetY
+	GetMenu          string             `bson:"getMenu"`          //증정 메뉴, buyXGetY
+	GetQty           int                `bson:"getQty"`           //증정 수량, buyXGetY
+	MinOrder         int                `bson:"minOrder"`         //최소 주문 금액 (메뉴 금액 기준)
+	StartAt          time.Time          `bson:"startAt"`          //사용 시작 시간
+	EndAt            time.Time          `bson:"endAt"`            //사용 종료 시간
+	UsageLimit       int                `bson:"usageLimit"`       //전체 사용 횟수, 0 : 제한 없음
+	PerCustomerLimit int                `bson:"perCustomerLimit"` //고객당 사용 횟수, 0 : 제한 없음
+	UsedCount        int                `bson:"usedCount"`        //사용된 횟수
+	Retired          bool               `bson:"retired"`          //사용 중지
+	CreatedAt        string             `bson:"createdAt"`        //등록 시간
+}
+
+// 고객별 사용 기록
+type Redemption struct {
+	PromotionID primitive.ObjectID   `bson:"promotionId"`
+	Pnum        string               `bson:"pnum"`
+	Count       int                  `bson:"count"`    //사용 횟수
+	OrderIDs    []primitive.ObjectID `bson:"orderIds"` //쿠폰을 사용한 주문
+}
+
+// 프로모션 정의 확인
+func (pr Promotion) Validate() error {
+	if len(pr.Code) <= 0 || len(pr.Name) <= 0 {
+		return fmt.Errorf("code and name not found")
+	}
+	if !pr.EndAt.After(pr.StartAt) {
+		return fmt.Errorf("endAt must be after startAt")
+	}
+	if pr.MinOrder < 0 || pr.UsageLimit < 0 || pr.PerCustomerLimit < 0 || pr.MaxDiscount < 0 {
+		return fmt.Errorf("minOrder, usageLimit, perCustomerLimit and maxDiscount must be 0 or more")
+	}
+	switch pr.Type {
+	case PromoPercent:
+		if pr.Value <= 0 || pr.Value > 100 {
+			return fmt.Errorf("percent value must be 1 to 100")
+		}
+	case PromoFixed:
+		if pr.Value <= 0 {
+			return fmt.Errorf("fixed value must be more than 0")
+		}
+	case PromoFreeDelivery:
+	case PromoBuyXGetY:
+		if len(pr.BuyMenu) <= 0 || len(pr.GetMenu) <= 0 || pr.BuyQty <= 0 || pr.GetQty <= 0 {
+			return fmt.Errorf("buyMenu, buyQty, getMenu and getQty not found")
+		}
+	default:
+		return fmt.Errorf("type must be percent, fixed, freeDelivery or buyXGetY")
+	}
+	return nil
+}
+
+// 주문에 사용 가능한지 확인, 사용 기간과 최소 주문 금액
+func (pr Promotion) Check(now time.Time, subtotal int) error {
+	if pr.Retired || now.Before(pr.StartAt) || !now.Before(pr.EndAt) {
+		return ErrPromotionExpired
+	}
+	if subtotal < pr.MinOrder {
+		return fmt.Errorf("%w, minimum order amount is %d", ErrPromotionNotApplicable, pr.MinOrder)
+	}
+	return nil
+}
+
+// 할인 금액 계산 (증정 메뉴 제외), 메뉴 금액과 배달비를 넘지 않음
+func (pr Promotion) Discount(order OrderList) int {
+	switch pr.Type {
+	case PromoPercent:
+		d := order.Subtotal * pr.Value / 100
+		if pr.MaxDiscount > 0 && d > pr.MaxDiscount {
+			d = pr.MaxDiscount
+		}
+		return d
+	case PromoFixed:
+		if pr.Value > order.Subtotal {
+			return order.Subtotal
+		}
+		return pr.Value
+	case PromoFreeDelivery:
+		return order.DeliveryFee
+	}
+	return 0
+}
+
+// 증정 메뉴 수량, 구매 메뉴 BuyQty개마다 GetQty개. 구매 메뉴가 BuyQty개 미만이면 0
+func (pr Promotion) FreeQty(order OrderList) int {
+	if pr.Type != PromoBuyXGetY {
+		return 0
+	}
+	count := 0
+	for _, item := range order.Items {
+		if item.Menu == pr.BuyMenu {
+			count++
+		}
+	}
+	return count / pr.BuyQty * pr.GetQty
+}
+
+// 프로모션 등록 (피주문자)
+func (p *Model) CreatePromotion(pr Promotion) (primitive.ObjectID, error) {
+	res, err := p.colPromotion.InsertOne(context.TODO(), pr)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return res.InsertedID.(primitive.ObjectID), nil
+}
+
+// 쿠폰 코드로 프로모션 조회
+func (p *Model) GetPromotionByCode(storeID primitive.ObjectID, code string) (Promotion, error) {
+	var pr Promotion
+	if err := p.colPromotion.FindOne(context.TODO(), bson.M{"storeId": storeID, "code": code}).Decode(&pr); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return pr, ErrPromotionNotFound
+		}
+		return pr, err
+	}
+	return pr, nil
+}
+
+// 사업장 프로모션 최신순 조회
+func (p *Model) GetPromotions(storeID primitive.ObjectID) ([]Promotion, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}})
+	cursor, err := p.colPromotion.Find(context.TODO(), bson.M{"storeId": storeID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	promotions := []Promotion{}
+	if err := cursor.All(context.TODO(), &promotions); err != nil {
+		return nil, err
+	}
+	return promotions, nil
+}
+
+// 프로모션 사용 중지 (피주문자)
+func (p *Model) RetirePromotion(storeID, promotionID primitive.ObjectID) error {
+	filter := bson.M{"_id": promotionID, "storeId": storeID}
+	if res, err := p.colPromotion.UpdateOne(context.TODO(), filter, bson.M{"$set": bson.M{"retired": true}}); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrPromotionNotFound
+	}
+	return nil
+}
+
+// 쿠폰 사용, 고객당 횟수와 전체 횟수를 조건부 증가로 확인해 동시 사용에도 제한 유지
+func (p *Model) RedeemPromotion(pr Promotion, pnum string, orderID primitive.ObjectID) error {
+	if len(pnum) <= 0 { //고객 번호가 없으면 모든 비회원 주문이 한 사용 기록을 공유
+		return ErrPromotionCustomerRequired
+	}
+	//고객당 횟수, (프로모션, 고객) unique 인덱스로 제한 초과시 upsert가 중복 에러
+	filter := bson.M{"promotionId": pr.ID, "pnum": pnum}
+	if pr.PerCustomerLimit > 0 {
+		filter["count"] = bson.M{"$lt": pr.PerCustomerLimit}
+	}
+	update := bson.M{"$inc": bson.M{"count": 1}, "$push": bson.M{"orderIds": orderID}}
+	for retried := false; ; retried = true {
+		_, err := p.colRedemption.UpdateOne(context.TODO(), filter, update, options.Update().SetUpsert(true))
+		if err == nil {
+			break
+		}
+		if !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+		if retried { //기록이 있는데도 조건에 맞지 않으면 제한 초과
+			return ErrPromotionCustomerLimit
+		}
+		//첫 사용이 동시에 upsert되면 한쪽이 중복 에러, 생성된 기록으로 다시 시도
+	}
+
+	//전체 횟수
+	promoFilter := bson.M{"_id": pr.ID, "retired": false}
+	if pr.UsageLimit > 0 {
+		promoFilter["usedCount"] = bson.M{"$lt": pr.UsageLimit}
+	}
+	res, err := p.colPromotion.UpdateOne(context.TODO(), promoFilter, bson.M{"$inc": bson.M{"usedCount": 1}})
+	if err != nil || res.MatchedCount <= 0 {
+		p.releaseRedemption(pr.ID, pnum, orderID)
+		if err != nil {
+			return err
+		}
+		return ErrPromotionExhausted
+	}
+	return nil
+}
+
+// 쿠폰 사용 취소, 주문 실패 및 주문 취소시 사용 횟수 복구
+func (p *Model) ReleasePromotion(promotionID primitive.ObjectID, pnum string, orderID primitive.ObjectID) error {
+	if ok, err := p.releaseRedemption(promotionID, pnum, orderID); err != nil || !ok {
+		return err
+	}
+	filter := bson.M{"_id": promotionID, "usedCount": bson.M{"$gt": 0}}
+	_, err := p.colPromotion.UpdateOne(context.TODO(), filter, bson.M{"$inc": bson.M{"usedCount": -1}})
+	return err
+}
+
+// 고객 사용 기록에서 주문 제거, 기록이 있었는지 반환
+func (p *Model) releaseRedemption(promotionID primitive.ObjectID, pnum string, orderID primitive.ObjectID) (bool, error) {
+	filter := bson.M{"promotionId": promotionID, "pnum": pnum, "orderIds": orderID}
+	update := bson.M{"$inc": bson.M{"count": -1}, "$pull": bson.M{"orderIds": orderID}}
+	res, err := p.colRedemption.UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount > 0, nil
+}
@@ -0,0 +1,36 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPromotionFreeQty(t *testing.T) {
+	now := time.Now()
+	pr := Promotion{Code: "BUY2", Name: "buy 2 get 1", StartAt: now, EndAt: now.Add(time.Hour), Type: PromoBuyXGetY, BuyMenu: "bulgogi", BuyQty: 2, GetMenu: "cola", GetQty: 1}
+	if err := pr.Validate(); err != nil {
+		t.Fatalf("buyQty 2 Validate() = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		items []string
+		want  int
+	}{
+		{"below buyQty", []string{"bulgogi"}, 0},
+		{"other menu", []string{"bulgogi", "whopper"}, 0},
+		{"meets buyQty", []string{"bulgogi", "bulgogi"}, 1},
+		{"twice buyQty", []string{"bulgogi", "bulgogi", "bulgogi", "bulgogi", "bulgogi"}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := OrderList{}
+			for _, menu := range tt.items {
+				order.Items = append(order.Items, OrderItem{Menu: menu})
+			}
+			if got := pr.FreeQty(order); got != tt.want {
+				t.Errorf("FreeQty(%v) = %d, want %d", tt.items, got, tt.want)
+			}
+		})
+	}
+}
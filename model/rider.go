@@ -0,0 +1,250 @@
+package model
+
+//rider.go : 배달원 정보, 배차, 배달 진행 및 위치 데이터 핸들링
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 배달원 상태
+const (
+	RiderOffline    = "offline"    //근무 종료
+	RiderAvailable  = "available"  //배차 대기
+	RiderAssigned   = "assigned"   //배차됨, 수락 대기
+	RiderDelivering = "delivering" //배달 수락 후 배달중
+)
+
+// 주문에 저장하는 배달 위치 기록 최대 개수
+const trackLimit = 100
+
+var (
+	ErrRiderNotFound    = errors.New("rider not found")
+	ErrRiderUnavailable = errors.New("rider is not available")
+	ErrNoRider          = errors.New("no available rider")
+	ErrNotAssigned      = errors.New("order is not assigned to this rider")
+)
+
+type Rider struct {
+	ID           primitive.ObjectID `bson:"_id,omitempty"`        //배달원 고유 번호
+	Name         string             `bson:"name"`                 //배달원 이름
+	Phone        string             `bson:"phone"`                //배달원 전화번호
+	Account      string             `bson:"account" json:"-"`     //배달원 계정 (Authorization)
+	Status       string             `bson:"status"`               //offline, available, assigned, delivering
+	OrderID      primitive.ObjectID `bson:"orderId,omitempty"`    //배달중인 주문
+	Lat          float64            `bson:"lat"`                  //현재 위도
+	Lng          float64            `bson:"lng"`                  //현재 경도
+	LocationAt   time.Time          `bson:"locationAt,omitempty"` //위치 갱신 시간
+	RegisteredAt string             `bson:"registeredAt"`         //등록 시간
+}
+
+// 주문의 배달 정보
+type Delivery struct {
+	RiderID     primitive.ObjectID `bson:"riderId"`               //배달원 고유 번호
+	RiderName   string             `bson:"riderName"`             //배달원 이름
+	RiderPhone  string             `bson:"riderPhone"`            //배달원 전화번호
+	AssignedAt  time.Time          `bson:"assignedAt"`            //배차 시간
+	AcceptedAt  time.Time          `bson:"acceptedAt,omitempty"`  //배달 수락 시간
+	PickedUpAt  time.Time          `bson:"pickedUpAt,omitempty"`  //픽업 시간
+	DeliveredAt time.Time          `bson:"deliveredAt,omitempty"` //배달 완료 시간
+	Lat         float64            `bson:"lat"`                   //배달원 최근 위도
+	Lng         float64            `bson:"lng"`                   //배달원 최근 경도
+	LocationAt  time.Time          `bson:"locationAt,omitempty"`  //배달원 위치 갱신 시간
+	Track       []TrackPoint       `bson:"track"`                 //배달원 위치 기록, 최근 trackLimit개
+}
+
+// 배달원 위치 기록
+type TrackPoint struct {
+	Lat float64   `bson:"lat"`
+	Lng float64   `bson:"lng"`
+	At  time.Time `bson:"at"`
+}
+
+// 배달원 등록 (관리자)
+func (p *Model) CreateRider(rider Rider) (primitive.ObjectID, error) {
+	res, err := p.colRider.InsertOne(context.TODO(), rider)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return res.InsertedID.(primitive.ObjectID), nil
+}
+
+// 배달원 고유 번호로 조회
+func (p *Model) GetRider(riderID primitive.ObjectID) (Rider, error) {
+	return p.findRider(bson.M{"_id": riderID})
+}
+
+// 배달원 계정으로 조회
+func (p *Model) GetRiderByAccount(account string) (Rider, error) {
+	return p.findRider(bson.M{"account": account})
+}
+
+func (p *Model) findRider(filter bson.M) (Rider, error) {
+	var rider Rider
+	if err := p.colRider.FindOne(context.TODO(), filter).Decode(&rider); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return rider, ErrRiderNotFound
+		}
+		return rider, err
+	}
+	return rider, nil
+}
+
+// 배차 대기중인 배달원 목록
+func (p *Model) GetAvailableRiders() ([]Rider, error) {
+	filter := bson.M{"status": RiderAvailable}
+	opts := options.Find().SetSort(bson.D{{Key: "locationAt", Value: -1}})
+
+	cursor, err := p.colRider.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	riders := []Rider{}
+	if err := cursor.All(context.TODO(), &riders); err != nil {
+		return nil, err
+	}
+	return riders, nil
+}
+
+// 근무 시작 및 종료, 배달중에는 변경 불가
+func (p *Model) SetRiderStatus(riderID primitive.ObjectID, status string) error {
+	filter := bson.M{"_id": riderID, "status": bson.M{"$in": bson.A{RiderOffline, RiderAvailable}}}
+	update := bson.M{"$set": bson.M{"status": status}}
+	if res, err := p.colRider.UpdateOne(context.TODO(), filter, update); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrRiderUnavailable
+	}
+	return nil
+}
+
+// 주문에 배달원 배차, 배차 대기중인 배달원만 가능 (동시 배차시 한 주문만 성공)
+func (p *Model) AssignRider(orderID, riderID primitive.ObjectID, t time.Time) (Rider, error) {
+	filter := bson.M{"_id": riderID, "status": RiderAvailable}
+	update := bson.M{"$set": bson.M{"status": RiderAssigned, "orderId": orderID}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var rider Rider
+	if err := p.colRider.FindOneAndUpdate(context.TODO(), filter, update, opts).Decode(&rider); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return rider, ErrRiderUnavailable
+		}
+		return rider, err
+	}
+
+	delivery := Delivery{RiderID: rider.ID, RiderName: rider.Name, RiderPhone: rider.Phone, AssignedAt: t, Lat: rider.Lat, Lng: rider.Lng, LocationAt: rider.LocationAt, Track: []TrackPoint{}}
+	orderFilter := bson.M{"_id": orderID, "delivery": nil} //배차되지 않은 주문만
+	if res, err := p.colOrderList.UpdateOne(context.TODO(), orderFilter, bson.M{"$set": bson.M{"delivery": delivery}}); err != nil || res.MatchedCount <= 0 {
+		p.releaseRider(riderID) //주문 배차 실패시 배달원 복구
+		if err == nil {
+			err = ErrNotAssigned
+		}
+		return rider, err
+	}
+	return rider, nil
+}
+
+// 배달원을 배차 대기 상태로 복구
+func (p *Model) releaseRider(riderID primitive.ObjectID) error {
+	update := bson.M{
+		"$set":   bson.M{"status": RiderAvailable},
+		"$unset": bson.M{"orderId": ""},
+	}
+	_, err := p.colRider.UpdateOne(context.TODO(), bson.M{"_id": riderID}, update)
+	return err
+}
+
+// 취소된 주문의 배달원을 배차 대기로 복구
+func (p *Model) CancelDelivery(riderID, orderID primitive.ObjectID) error {
+	filter := bson.M{"_id": riderID, "orderId": orderID}
+	update := bson.M{
+		"$set":   bson.M{"status": RiderAvailable},
+		"$unset": bson.M{"orderId": ""},
+	}
+	_, err := p.colRider.UpdateOne(context.TODO(), filter, update)
+	return err
+}
+
+// 배차 수락 (배달원)
+func (p *Model) AcceptDelivery(riderID, orderID primitive.ObjectID, t time.Time) error {
+	filter := bson.M{"_id": riderID, "orderId": orderID, "status": RiderAssigned}
+	if res, err := p.colRider.UpdateOne(context.TODO(), filter, bson.M{"$set": bson.M{"status": RiderDelivering}}); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrNotAssigned
+	}
+	return p.setDeliveryTime(riderID, orderID, "acceptedAt", t)
+}
+
+// 배차 거절 (배달원), 주문은 다시 배차 가능
+func (p *Model) RejectDelivery(riderID, orderID primitive.ObjectID) error {
+	filter := bson.M{"_id": riderID, "orderId": orderID, "status": RiderAssigned}
+	if res, err := p.colRider.UpdateOne(context.TODO(), filter, bson.M{"$set": bson.M{"status": RiderAvailable}, "$unset": bson.M{"orderId": ""}}); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrNotAssigned
+	}
+	orderFilter := bson.M{"_id": orderID, "delivery.riderId": riderID}
+	if _, err := p.colOrderList.UpdateOne(context.TODO(), orderFilter, bson.M{"$unset": bson.M{"delivery": ""}}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// 픽업 시간 기록 (배달원)
+func (p *Model) PickUpDelivery(riderID, orderID primitive.ObjectID, t time.Time) error {
+	return p.setDeliveryTime(riderID, orderID, "pickedUpAt", t)
+}
+
+// 배달 완료 시간 기록 후 배달원은 배차 대기 (배달원)
+func (p *Model) CompleteDelivery(riderID, orderID primitive.ObjectID, t time.Time) error {
+	if err := p.setDeliveryTime(riderID, orderID, "deliveredAt", t); err != nil {
+		return err
+	}
+	return p.releaseRider(riderID)
+}
+
+func (p *Model) setDeliveryTime(riderID, orderID primitive.ObjectID, field string, t time.Time) error {
+	filter := bson.M{"_id": orderID, "delivery.riderId": riderID}
+	if res, err := p.colOrderList.UpdateOne(context.TODO(), filter, bson.M{"$set": bson.M{"delivery." + field: t}}); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrNotAssigned
+	}
+	return nil
+}
+
+// 배달원 위치 갱신, 배달중인 주문이 있으면 주문에도 위치 기록
+func (p *Model) UpdateRiderLocation(riderID primitive.ObjectID, lat, lng float64, t time.Time) (Rider, error) {
+	update := bson.M{"$set": bson.M{"lat": lat, "lng": lng, "locationAt": t}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var rider Rider
+	if err := p.colRider.FindOneAndUpdate(context.TODO(), bson.M{"_id": riderID}, update, opts).Decode(&rider); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return rider, ErrRiderNotFound
+		}
+		return rider, err
+	}
+	if rider.OrderID.IsZero() {
+		return rider, nil
+	}
+
+	filter := bson.M{"_id": rider.OrderID, "delivery.riderId": riderID}
+	orderUpdate := bson.M{
+		"$set": bson.M{"delivery.lat": lat, "delivery.lng": lng, "delivery.locationAt": t},
+		"$push": bson.M{"delivery.track": bson.M{
+			"$each":  bson.A{TrackPoint{Lat: lat, Lng: lng, At: t}},
+			"$slice": -trackLimit,
+		}},
+	}
+	if _, err := p.colOrderList.UpdateOne(context.TODO(), filter, orderUpdate); err != nil {
+		return rider, err
+	}
+	return rider, nil
+}
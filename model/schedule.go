@@ -0,0 +1,67 @@
+package model
+
+//schedule.go : 예약 주문 최소 예약 시간, 준비 시간 확인 및 예약 주문 주방 전달 데이터 핸들링
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// 최소 예약 시간(분), 0이면 기본값
+func (s Store) MinLeadTime(defaultMinutes int) time.Duration {
+	if s.LeadTime > 0 {
+		return time.Duration(s.LeadTime) * time.Minute
+	}
+	return time.Duration(defaultMinutes) * time.Minute
+}
+
+// 예약 시간 기준 주방 전달 시간, 준비 시간(분)만큼 먼저 전달. 준비 시간이 0이면 기본값
+func (s Store) ReleaseTime(scheduled time.Time, defaultMinutes int) time.Time {
+	prep := s.PrepTime
+	if prep <= 0 {
+		prep = defaultMinutes
+	}
+	return scheduled.Add(-time.Duration(prep) * time.Minute)
+}
+
+// 전달 시간이 지난 예약 주문을 접수중으로 변경, 변경된 주문 반환
+func (p *Model) ReleaseScheduledOrders(now time.Time) ([]OrderList, error) {
+	filter := bson.M{"state": StateScheduled, "releaseAt": bson.M{"$lte": now}}
+	cursor, err := p.colOrderList.Find(context.TODO(), filter)
+	if err != nil {
+		return nil, err
+	}
+	due := []OrderList{}
+	if err := cursor.All(context.TODO(), &due); err != nil {
+		return nil, err
+	}
+
+	released := []OrderList{}
+	for _, order := range due { //그 사이 상태가 바뀐 주문은 제외
+		if err := p.UpdateStateByID(order.StoreID, order.ID, StateScheduled, StateReceived); err != nil {
+			continue
+		}
+		order.State = StateReceived
+		released = append(released, order)
+	}
+	return released, nil
+}
+
+// 사업장의 대기중인 예약 주문 예약 시간순 조회 (피주문자)
+func (p *Model) GetScheduledOrders(storeID primitive.ObjectID) ([]OrderList, error) {
+	filter := bson.M{"storeId": storeID, "state": StateScheduled}
+	opts := options.Find().SetSort(bson.D{{Key: "releaseAt", Value: 1}})
+
+	cursor, err := p.colOrderList.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	orders := []OrderList{}
+	if err := cursor.All(context.TODO(), &orders); err != nil {
+		return nil, err
+	}
+	return orders, nil
+}
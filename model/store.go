@@ -0,0 +1,274 @@
+package model
+
+//store.go : 사업장(매장) 정보, 영업 시간, 배달 가능 거리, 사업장 계정 데이터 핸들링
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var (
+	ErrStoreNotFound = errors.New("store not found")
+	ErrStaffExists   = errors.New("staff account already belongs to a store")
+)
+
+type Store struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty"`  //사업장 고유 번호
+	Name           string             `bson:"name"`           //사업장 이름
+	Address        string             `bson:"address"`        //사업장 주소
+	Phone          string             `bson:"phone"`          //사업장 전화번호
+	Lat            float64            `bson:"lat"`            //위도
+	Lng            float64            `bson:"lng"`            //경도
+	TimeZone       string             `bson:"timeZone"`       //사업장 시간대 ex. Asia/Seoul
+	OpeningHours   []OpeningHour      `bson:"openingHours"`   //요일별 영업 시간, 없으면 항상 영업
+	Holidays       []Holiday          `bson:"holidays"`       //휴무일
+	Paused         bool               `bson:"paused"`         //주문 일시 중지
+	PauseReason    string             `bson:"pauseReason"`    //일시 중지 사유
+	LeadTime       int                `bson:"leadTime"`       //예약 주문 최소 예약 시간 (분), 0이면 기본값
+	PrepTime       int                `bson:"prepTime"`       //예약 주문 준비 시간 (분), 예약 시간 전 주방 전달. 0이면 기본값
+	DeliveryRadius int                `bson:"deliveryRadius"` //배달 가능 거리 (m), 0이면 제한 없음, 배달 구역이 없을 때만 사용
+	DeliveryZones  []DeliveryZone     `bson:"deliveryZones"`  //배달 구역, 구역별 최소 주문 금액과 배달비
+	Staff          []string           `bson:"staff" json:"-"` //사업장 계정 (Authorization)
+	CreatedAt      string             `bson:"createdAt"`      //등록 시간
+}
+
+// 요일별 영업 시간, 자정을 넘기는 영업은 Close가 Open보다 이른 시간 ex. 18:00 ~ 02:00
+type OpeningHour struct {
+	Weekday int    `bson:"weekday"` //0(일) ~ 6(토)
+	Open    string `bson:"open"`    //HH:MM
+	Close   string `bson:"close"`   //HH:MM
+}
+
+// 사업장 정보 부분 수정 항목, nil이 아닌 항목만 변경
+type StorePatch struct {
+	Name           *string
+	Address        *string
+	Phone          *string
+	Lat            *float64
+	Lng            *float64
+	TimeZone       *string
+	OpeningHours   *[]OpeningHour
+	Holidays       *[]Holiday
+	LeadTime       *int
+	PrepTime       *int
+	DeliveryRadius *int
+	DeliveryZones  *[]DeliveryZone
+}
+
+// 변경할 항목의 $set 내용
+func (m StorePatch) setFields() bson.M {
+	set := bson.M{}
+	if m.Name != nil {
+		set["name"] = *m.Name
+	}
+	if m.Address != nil {
+		set["address"] = *m.Address
+	}
+	if m.Phone != nil {
+		set["phone"] = *m.Phone
+	}
+	if m.Lat != nil {
+		set["lat"] = *m.Lat
+	}
+	if m.Lng != nil {
+		set["lng"] = *m.Lng
+	}
+	if m.TimeZone != nil {
+		set["timeZone"] = *m.TimeZone
+	}
+	if m.OpeningHours != nil {
+		set["openingHours"] = *m.OpeningHours
+	}
+	if m.Holidays != nil {
+		set["holidays"] = *m.Holidays
+	}
+	if m.LeadTime != nil {
+		set["leadTime"] = *m.LeadTime
+	}
+	if m.PrepTime != nil {
+		set["prepTime"] = *m.PrepTime
+	}
+	if m.DeliveryRadius != nil {
+		set["deliveryRadius"] = *m.DeliveryRadius
+	}
+	if m.DeliveryZones != nil {
+		set["deliveryZones"] = *m.DeliveryZones
+	}
+	return set
+}
+
+// 신규 사업장 등록시 입력된 항목 적용
+func (m StorePatch) Apply(s *Store) {
+	if m.Name != nil {
+		s.Name = *m.Name
+	}
+	if m.Address != nil {
+		s.Address = *m.Address
+	}
+	if m.Phone != nil {
+		s.Phone = *m.Phone
+	}
+	if m.Lat != nil {
+		s.Lat = *m.Lat
+	}
+	if m.Lng != nil {
+		s.Lng = *m.Lng
+	}
+	if m.TimeZone != nil {
+		s.TimeZone = *m.TimeZone
+	}
+	if m.OpeningHours != nil {
+		s.OpeningHours = *m.OpeningHours
+	}
+	if m.Holidays != nil {
+		s.Holidays = *m.Holidays
+	}
+	if m.LeadTime != nil {
+		s.LeadTime = *m.LeadTime
+	}
+	if m.PrepTime != nil {
+		s.PrepTime = *m.PrepTime
+	}
+	if m.DeliveryRadius != nil {
+		s.DeliveryRadius = *m.DeliveryRadius
+	}
+	if m.DeliveryZones != nil {
+		s.DeliveryZones = *m.DeliveryZones
+	}
+}
+
+// 영업 시간 정의 확인
+func ValidateOpeningHours(hours []OpeningHour) error {
+	for _, h := range hours {
+		if h.Weekday < 0 || h.Weekday > 6 {
+			return fmt.Errorf("weekday must be 0(sunday) ~ 6(saturday), %d", h.Weekday)
+		}
+		if _, err := time.Parse("15:04", h.Open); err != nil {
+			return fmt.Errorf("open must be HH:MM, %s", h.Open)
+		}
+		if _, err := time.Parse("15:04", h.Close); err != nil {
+			return fmt.Errorf("close must be HH:MM, %s", h.Close)
+		}
+	}
+	return nil
+}
+
+// 사업장 등록 (관리자)
+func (p *Model) CreateStore(store Store) (primitive.ObjectID, error) {
+	res, err := p.colStore.InsertOne(context.TODO(), store)
+	if err != nil {
+		return primitive.NilObjectID, err
+	}
+	return res.InsertedID.(primitive.ObjectID), nil
+}
+
+// 사업장 고유 번호로 조회
+func (p *Model) GetStore(storeID primitive.ObjectID) (Store, error) {
+	filter := bson.M{"_id": storeID}
+
+	var store Store
+	if err := p.colStore.FindOne(context.TODO(), filter).Decode(&store); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return store, ErrStoreNotFound
+		}
+		return store, err
+	}
+	return store, nil
+}
+
+// 사업장 계정으로 소속 사업장 조회
+func (p *Model) GetStoreByStaff(account string) (Store, error) {
+	filter := bson.M{"staff": account}
+
+	var store Store
+	if err := p.colStore.FindOne(context.TODO(), filter).Decode(&store); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return store, ErrStoreNotFound
+		}
+		return store, err
+	}
+	return store, nil
+}
+
+// 전체 사업장 이름순 조회 (주문자)
+func (p *Model) GetStoreList() ([]Store, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "name", Value: 1}})
+
+	cursor, err := p.colStore.Find(context.TODO(), bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	stores := []Store{}
+	if err := cursor.All(context.TODO(), &stores); err != nil {
+		return nil, err
+	}
+	return stores, nil
+}
+
+// 사업장 정보 수정 (피주문자)
+func (p *Model) UpdateStore(storeID primitive.ObjectID, patch StorePatch) error {
+	set := patch.setFields()
+	if len(set) <= 0 {
+		return nil
+	}
+
+	filter := bson.M{"_id": storeID}
+	if res, err := p.colStore.UpdateOne(context.TODO(), filter, bson.M{"$set": set}); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrStoreNotFound
+	}
+	return nil
+}
+
+// 사업장 계정 추가 (관리자), 계정은 한 사업장에만 소속
+func (p *Model) AddStaff(storeID primitive.ObjectID, account string) error {
+	if store, err := p.GetStoreByStaff(account); err == nil && store.ID != storeID {
+		return ErrStaffExists
+	} else if err != nil && err != ErrStoreNotFound {
+		return err
+	}
+
+	filter := bson.M{"_id": storeID}
+	update := bson.M{"$addToSet": bson.M{"staff": account}}
+	if res, err := p.colStore.UpdateOne(context.TODO(), filter, update); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrStoreNotFound
+	}
+	return nil
+}
+
+// 사업장 계정 삭제 (관리자)
+func (p *Model) RemoveStaff(storeID primitive.ObjectID, account string) error {
+	filter := bson.M{"_id": storeID}
+	update := bson.M{"$pull": bson.M{"staff": account}}
+	if res, err := p.colStore.UpdateOne(context.TODO(), filter, update); err != nil {
+		return err
+	} else if res.MatchedCount <= 0 {
+		return ErrStoreNotFound
+	}
+	return nil
+}
+
+// 사업장 구분 이전에 등록된 메뉴, 주문, 리뷰를 해당 사업장으로 지정 (관리자)
+func (p *Model) AssignLegacyData(storeID primitive.ObjectID) (map[string]int64, error) {
+	filter := bson.M{"storeId": bson.M{"$exists": false}}
+	update := bson.M{"$set": bson.M{"storeId": storeID}}
+
+	result := map[string]int64{}
+	for name, col := range map[string]*mongo.Collection{"menu": p.colMenu, "order": p.colOrderList, "review": p.colReview} {
+		res, err := col.UpdateMany(context.TODO(), filter, update)
+		if err != nil {
+			return result, err
+		}
+		result[name] = res.ModifiedCount
+	}
+	return result, nil
+}
@@ -0,0 +1,50 @@
+package model
+
+//tax.go : 통화 및 부가세 포함 가격의 세금 계산
+
+const CurrencyKRW = "KRW" //기본 통화
+
+// 통화별 소수 자릿수, 금액은 통화의 최소 단위 정수로 저장 ex. KRW 1원, USD 1센트
+var currencyDigits = map[string]int{
+	"KRW": 0,
+	"JPY": 0,
+	"USD": 2,
+	"EUR": 2,
+}
+
+// 부가세 포함 금액의 공급가액과 부가세
+type TaxBreakdown struct {
+	Currency string //통화
+	VATRate  int    //부가세율 (%)
+	Supply   int    //공급가액
+	VAT      int    //부가세
+	Total    int    //합계 (부가세 포함)
+}
+
+// 지원하는 통화인지 확인
+func ValidCurrency(currency string) bool {
+	_, ok := currencyDigits[currency]
+	return ok
+}
+
+// 통화 소수 자릿수, 모르는 통화는 0
+func CurrencyDigits(currency string) int {
+	return currencyDigits[currency]
+}
+
+// 부가세 포함 금액을 공급가액과 부가세로 나누기, 부가세는 합계 × 세율 / (100 + 세율)의 최소 단위 미만 버림
+func SplitVAT(total, rate int, currency string) TaxBreakdown {
+	vat := 0
+	if rate > 0 && total > 0 {
+		vat = total * rate / (100 + rate)
+	}
+	return TaxBreakdown{Currency: currency, VATRate: rate, Supply: total - vat, VAT: vat, Total: total}
+}
+
+// 주문 세금 계산, 주문시 기록한 통화와 세율 기준. 기록이 없는 이전 주문은 기본값 사용
+func (o OrderList) Tax(currency string, rate int) TaxBreakdown {
+	if len(o.Currency) > 0 {
+		currency, rate = o.Currency, o.VATRate
+	}
+	return SplitVAT(o.Price, rate, currency)
+}
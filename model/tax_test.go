@@ -0,0 +1,47 @@
+package model
+
+import "testing"
+
+func TestSplitVAT(t *testing.T) {
+	tests := []struct {
+		name        string
+		total, rate int
+		currency    string
+		supply, vat int
+	}{
+		{"exact", 11000, 10, "KRW", 10000, 1000},
+		{"round down vat", 10999, 10, "KRW", 10000, 999},
+		{"smallest unit", 1, 10, "KRW", 1, 0},
+		{"eleven won", 11, 10, "KRW", 10, 1},
+		{"zero rate", 11000, 0, "KRW", 11000, 0},
+		{"zero total", 0, 10, "KRW", 0, 0},
+		{"negative total", -1100, 10, "KRW", -1100, 0},
+		{"cents", 1099, 20, "USD", 916, 183},
+		{"full rate", 1000, 100, "EUR", 500, 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitVAT(tt.total, tt.rate, tt.currency)
+			if got.Supply != tt.supply || got.VAT != tt.vat || got.Total != tt.total {
+				t.Errorf("SplitVAT(%d, %d) = %+v, want supply %d vat %d", tt.total, tt.rate, got, tt.supply, tt.vat)
+			}
+			if got.Supply+got.VAT != got.Total {
+				t.Errorf("supply %d + vat %d != total %d", got.Supply, got.VAT, got.Total)
+			}
+			if got.Currency != tt.currency || got.VATRate != tt.rate {
+				t.Errorf("currency %s rate %d, want %s %d", got.Currency, got.VATRate, tt.currency, tt.rate)
+			}
+		})
+	}
+}
+
+func TestOrderTax(t *testing.T) {
+	recorded := OrderList{Price: 1200, Currency: "USD", VATRate: 20}
+	if got := recorded.Tax("KRW", 10); got.Currency != "USD" || got.VATRate != 20 || got.VAT != 200 {
+		t.Errorf("recorded order Tax() = %+v, want USD 20%% vat 200", got)
+	}
+	legacy := OrderList{Price: 11000}
+	if got := legacy.Tax("KRW", 10); got.Currency != "KRW" || got.VATRate != 10 || got.VAT != 1000 {
+		t.Errorf("legacy order Tax() = %+v, want KRW 10%% vat 1000", got)
+	}
+}
@@ -0,0 +1,112 @@
+package model
+
+//zone.go : 사업장 배달 구역(반경, 다각형), 구역별 최소 주문 금액과 배달비 확인
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// 배달 구역 종류
+const (
+	ZoneRadius  = "radius"  //사업장 좌표 기준 반경
+	ZonePolygon = "polygon" //좌표 목록으로 만든 다각형
+)
+
+var (
+	ErrOutOfZone     = errors.New("address is outside of delivery zones")
+	ErrNoCoordinates = errors.New("address has no coordinates")
+)
+
+// 배달 구역, 사업장에 정의한 순서대로 확인해 처음 포함되는 구역 적용
+type DeliveryZone struct {
+	Name     string  `bson:"name"`     //구역 이름
+	Type     string  `bson:"type"`     //radius, polygon
+	Radius   int     `bson:"radius"`   //반경 (m), radius 구역
+	Polygon  []Point `bson:"polygon"`  //꼭지점 좌표, polygon 구역
+	MinOrder int     `bson:"minOrder"` //최소 주문 금액
+	Fee      int     `bson:"fee"`      //배달비
+}
+
+// 위도, 경도 좌표
+type Point struct {
+	Lat float64 `bson:"lat"`
+	Lng float64 `bson:"lng"`
+}
+
+// 배달 구역 정의 확인
+func ValidateDeliveryZones(zones []DeliveryZone) error {
+	for _, z := range zones {
+		if len(z.Name) <= 0 {
+			return fmt.Errorf("zone name not found")
+		}
+		if z.MinOrder < 0 || z.Fee < 0 {
+			return fmt.Errorf("%s minOrder and fee must be 0 or more", z.Name)
+		}
+		switch z.Type {
+		case ZoneRadius:
+			if z.Radius <= 0 {
+				return fmt.Errorf("%s radius must be more than 0", z.Name)
+			}
+		case ZonePolygon:
+			if len(z.Polygon) < 3 {
+				return fmt.Errorf("%s polygon needs at least 3 points", z.Name)
+			}
+		default:
+			return fmt.Errorf("%s type must be radius or polygon", z.Name)
+		}
+	}
+	return nil
+}
+
+// 두 좌표 사이 거리 (m), 하버사인 공식
+func Distance(lat1, lng1, lat2, lng2 float64) float64 {
+	const earthRadius = 6371000.0
+	rad := math.Pi / 180
+	dLat := (lat2 - lat1) * rad
+	dLng := (lng2 - lng1) * rad
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1*rad)*math.Cos(lat2*rad)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// 좌표가 구역에 포함되는지 확인, 반경은 사업장 좌표 기준
+func (z DeliveryZone) Contains(s Store, lat, lng float64) bool {
+	switch z.Type {
+	case ZoneRadius:
+		return Distance(s.Lat, s.Lng, lat, lng) <= float64(z.Radius)
+	case ZonePolygon: //좌표에서 나간 반직선이 변과 만나는 횟수가 홀수면 내부
+		inside := false
+		for i, j := 0, len(z.Polygon)-1; i < len(z.Polygon); j, i = i, i+1 {
+			a, b := z.Polygon[i], z.Polygon[j]
+			if (a.Lat > lat) != (b.Lat > lat) && lng < (b.Lng-a.Lng)*(lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+				inside = !inside
+			}
+		}
+		return inside
+	}
+	return false
+}
+
+// 배달 주소가 포함되는 배달 구역 찾기
+// 구역이 없으면 배달 가능 거리(deliveryRadius)만 확인하고 배달비 없음, 둘다 없으면 제한 없음
+func (s Store) FindZone(addr *Address) (DeliveryZone, error) {
+	if len(s.DeliveryZones) <= 0 && s.DeliveryRadius <= 0 {
+		return DeliveryZone{}, nil
+	}
+	if addr == nil || (addr.Lat == 0 && addr.Lng == 0) {
+		return DeliveryZone{}, ErrNoCoordinates
+	}
+
+	if len(s.DeliveryZones) <= 0 {
+		if Distance(s.Lat, s.Lng, addr.Lat, addr.Lng) > float64(s.DeliveryRadius) {
+			return DeliveryZone{}, ErrOutOfZone
+		}
+		return DeliveryZone{}, nil
+	}
+	for _, z := range s.DeliveryZones {
+		if z.Contains(s, addr.Lat, addr.Lng) {
+			return z, nil
+		}
+	}
+	return DeliveryZone{}, ErrOutOfZone
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"math"
+	"testing"
+)
+
+// 테스트 사업장 좌표 (서울시청)
+var testStore = Store{Lat: 37.5663, Lng: 126.9779}
+
+// 사업장 좌표 기준 북쪽으로 m만큼 떨어진 위도
+func northOf(lat float64, m float64) float64 {
+	return lat + m/6371000.0*180/math.Pi
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lng1, lat2, lng2 float64
+		want, delta            float64
+	}{
+		{"same point", 37.5663, 126.9779, 37.5663, 126.9779, 0, 0.001},
+		{"1km north", 37.5663, 126.9779, northOf(37.5663, 1000), 126.9779, 1000, 0.5},
+		{"seoul to busan", 37.5663, 126.9779, 35.1796, 129.0756, 325000, 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Distance(tt.lat1, tt.lng1, tt.lat2, tt.lng2)
+			if math.Abs(got-tt.want) > tt.delta {
+				t.Errorf("Distance() = %f, want %f ± %f", got, tt.want, tt.delta)
+			}
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	square := DeliveryZone{Name: "square", Type: ZonePolygon, Polygon: []Point{
+		{Lat: 37.56, Lng: 126.97}, {Lat: 37.56, Lng: 126.99}, {Lat: 37.58, Lng: 126.99}, {Lat: 37.58, Lng: 126.97},
+	}}
+	radius := DeliveryZone{Name: "1km", Type: ZoneRadius, Radius: 1000}
+
+	tests := []struct {
+		name     string
+		zone     DeliveryZone
+		lat, lng float64
+		want     bool
+	}{
+		{"inside polygon", square, 37.57, 126.98, true},
+		{"outside polygon east", square, 37.57, 127.00, false},
+		{"outside polygon south", square, 37.55, 126.98, false},
+		{"radius center", radius, testStore.Lat, testStore.Lng, true},
+		{"radius inside boundary", radius, northOf(testStore.Lat, 999), testStore.Lng, true},
+		{"radius outside boundary", radius, northOf(testStore.Lat, 1001), testStore.Lng, false},
+		{"unknown type", DeliveryZone{Type: "circle", Radius: 1000}, testStore.Lat, testStore.Lng, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.zone.Contains(testStore, tt.lat, tt.lng); got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindZone(t *testing.T) {
+	near := DeliveryZone{Name: "near", Type: ZoneRadius, Radius: 1000, MinOrder: 10000, Fee: 1000}
+	far := DeliveryZone{Name: "far", Type: ZoneRadius, Radius: 3000, MinOrder: 20000, Fee: 3000}
+	zoned := testStore
+	zoned.DeliveryZones = []DeliveryZone{near, far} //겹치는 구역은 앞 구역 우선
+	radiusOnly := testStore
+	radiusOnly.DeliveryRadius = 2000
+
+	tests := []struct {
+		name    string
+		store   Store
+		addr    *Address
+		want    string
+		wantErr error
+	}{
+		{"no zones no radius", testStore, nil, "", nil},
+		{"no coordinates", zoned, &Address{}, "", ErrNoCoordinates},
+		{"nil address", zoned, nil, "", ErrNoCoordinates},
+		{"overlap picks first zone", zoned, &Address{Lat: northOf(testStore.Lat, 500), Lng: testStore.Lng}, "near", nil},
+		{"second zone only", zoned, &Address{Lat: northOf(testStore.Lat, 2000), Lng: testStore.Lng}, "far", nil},
+		{"outside all zones", zoned, &Address{Lat: northOf(testStore.Lat, 5000), Lng: testStore.Lng}, "", ErrOutOfZone},
+		{"radius only inside", radiusOnly, &Address{Lat: northOf(testStore.Lat, 1500), Lng: testStore.Lng}, "", nil},
+		{"radius only outside", radiusOnly, &Address{Lat: northOf(testStore.Lat, 2500), Lng: testStore.Lng}, "", ErrOutOfZone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zone, err := tt.store.FindZone(tt.addr)
+			if err != tt.wantErr {
+				t.Fatalf("FindZone() error = %v, want %v", err, tt.wantErr)
+			}
+			if zone.Name != tt.want {
+				t.Errorf("FindZone() zone = %q, want %q", zone.Name, tt.want)
+			}
+		})
+	}
+}
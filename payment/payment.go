@@ -0,0 +1,144 @@
+package payment
+
+//payment.go : 결제 대행사(gateway) 인터페이스 및 개발용 가짜 결제 구현
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ErrDeclined       = errors.New("payment declined")
+	ErrIntentNotFound = errors.New("payment intent not found")
+	ErrInvalidState   = errors.New("invalid payment state")
+	ErrAmount         = errors.New("invalid payment amount")
+)
+
+// 결제 요청, 주문자는 ClientSecret으로 결제 수단을 입력해 승인 토큰을 받음
+type Intent struct {
+	ID           string //결제 요청 번호
+	ClientSecret string //주문자 결제 화면에서 사용
+	Amount       int    //결제 금액
+}
+
+// 결제 대행사 인터페이스, 실제 PG사 연동은 같은 인터페이스로 구현
+type Gateway interface {
+	Name() string
+	CreateIntent(orderID string, amount int) (Intent, error)
+	Authorize(intentID, token string) error             //승인 (금액 홀드), 거절시 ErrDeclined
+	Capture(intentID string, amount int) error          //승인 금액 내에서 매입
+	Void(intentID string) error                         //매입 전 승인 취소
+	Refund(intentID string, amount int) (string, error) //매입 후 환불, 환불 번호 반환
+}
+
+// 설정의 이름으로 결제 대행사 선택
+func NewGateway(name string) (Gateway, error) {
+	switch name {
+	case "", "fake":
+		return NewFakeGateway(), nil
+	}
+	return nil, fmt.Errorf("unknown payment gateway %s", name)
+}
+
+// 개발 및 테스트용 가짜 결제, 메모리에만 저장
+// 승인 토큰이 "decline"으로 시작하면 거절, 그 외에는 승인
+type FakeGateway struct {
+	mu      sync.Mutex
+	intents map[string]*fakeIntent
+}
+
+type fakeIntent struct {
+	amount   int
+	status   string //created, authorized, captured, voided
+	captured int
+	refunded int
+}
+
+func NewFakeGateway() *FakeGateway {
+	return &FakeGateway{intents: map[string]*fakeIntent{}}
+}
+
+func (g *FakeGateway) Name() string {
+	return "fake"
+}
+
+func (g *FakeGateway) CreateIntent(orderID string, amount int) (Intent, error) {
+	if amount < 0 {
+		return Intent{}, ErrAmount
+	}
+	id := "pi_" + primitive.NewObjectID().Hex()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.intents[id] = &fakeIntent{amount: amount, status: "created"}
+	return Intent{ID: id, ClientSecret: id + "_secret_" + orderID, Amount: amount}, nil
+}
+
+func (g *FakeGateway) Authorize(intentID, token string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	in, ok := g.intents[intentID]
+	if !ok {
+		return ErrIntentNotFound
+	}
+	if in.status != "created" {
+		return ErrInvalidState
+	}
+	if strings.HasPrefix(token, "decline") {
+		return ErrDeclined
+	}
+	in.status = "authorized"
+	return nil
+}
+
+func (g *FakeGateway) Capture(intentID string, amount int) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	in, ok := g.intents[intentID]
+	if !ok {
+		return ErrIntentNotFound
+	}
+	if in.status != "authorized" {
+		return ErrInvalidState
+	}
+	if amount < 0 || amount > in.amount {
+		return ErrAmount
+	}
+	in.status = "captured"
+	in.captured = amount
+	return nil
+}
+
+func (g *FakeGateway) Void(intentID string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	in, ok := g.intents[intentID]
+	if !ok {
+		return ErrIntentNotFound
+	}
+	if in.status != "created" && in.status != "authorized" {
+		return ErrInvalidState
+	}
+	in.status = "voided"
+	return nil
+}
+
+func (g *FakeGateway) Refund(intentID string, amount int) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	in, ok := g.intents[intentID]
+	if !ok {
+		return "", ErrIntentNotFound
+	}
+	if in.status != "captured" {
+		return "", ErrInvalidState
+	}
+	if amount <= 0 || in.refunded+amount > in.captured {
+		return "", ErrAmount
+	}
+	in.refunded += amount
+	return "re_" + primitive.NewObjectID().Hex(), nil
+}
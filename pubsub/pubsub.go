@@ -0,0 +1,135 @@
+package pubsub
+
+//pubsub.go : 주문 상태 변경 등 실시간 이벤트 발행/구독 인터페이스 및 프로세스 내부 구현
+import (
+	"sync"
+	"time"
+)
+
+// 이벤트 종류
+const (
+	EventState     = "state"     //주문 상태 변경
+	EventUpdated   = "updated"   //주문 메뉴 변경 및 추가
+	EventRider     = "rider"     //배달원 배차, 수락, 거절
+	EventLocation  = "location"  //배달원 위치 갱신
+	EventNew       = "new"       //주방에 새 주문 도착
+	EventAcked     = "acked"     //주방에서 주문 확인
+	EventCancelled = "cancelled" //주문 취소
+	EventSnapshot  = "snapshot"  //구독 시작시 처리중인 주문 목록
+)
+
+// 주방 화면 알림음
+const (
+	SoundNewOrder  = "new_order"
+	SoundModified  = "order_modified"
+	SoundCancelled = "order_cancelled"
+)
+
+// 주방 화면 알림음 정보
+type Alert struct {
+	Sound  string `json:"sound"`  //알림음 종류
+	Repeat bool   `json:"repeat"` //주문 확인(ack)할 때까지 반복
+}
+
+// 구독자에게 전달하는 이벤트
+type Event struct {
+	Type    string      `json:"type"`            //이벤트 종류
+	OrderID string      `json:"orderId"`         //주문 번호
+	State   string      `json:"state,omitempty"` //이벤트 발생 시점의 주문 상태, 위치 갱신은 없음
+	Data    interface{} `json:"data,omitempty"`  //이벤트별 추가 정보
+	Alert   *Alert      `json:"alert,omitempty"` //주방 화면 알림음, 알림이 필요한 이벤트만
+	At      time.Time   `json:"at"`              //발생 시간
+}
+
+// 이벤트 발행/구독 인터페이스, 추후 Redis, NATS 등 외부 브로커로 교체 가능
+type Broker interface {
+	Publish(topic string, ev Event)
+	Subscribe(topic string) (<-chan Event, func()) //이벤트 채널, 구독 해제 함수 반환
+	Close()                                        //모든 구독 종료, 구독 채널은 닫힘
+}
+
+// 주문별 구독 topic
+func OrderTopic(orderID string) string {
+	return "order:" + orderID
+}
+
+// 사업장별 구독 topic, 주방 화면에서 사용
+func StoreTopic(storeID string) string {
+	return "store:" + storeID
+}
+
+// 프로세스 내부 브로커, 서버 한 대에서만 동작
+type MemoryBroker struct {
+	mu     sync.Mutex
+	subs   map[string]map[chan Event]struct{}
+	buffer int
+	closed bool
+}
+
+// buffer : 구독자별 대기 이벤트 개수, 가득 차면 느린 구독자의 이벤트는 버림
+func NewMemoryBroker(buffer int) *MemoryBroker {
+	if buffer <= 0 {
+		buffer = 16
+	}
+	return &MemoryBroker{subs: map[string]map[chan Event]struct{}{}, buffer: buffer}
+}
+
+func (b *MemoryBroker) Publish(topic string, ev Event) {
+	if ev.At.IsZero() {
+		ev.At = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for ch := range b.subs[topic] {
+		select {
+		case ch <- ev:
+		default: //발행자가 구독자를 기다리지 않도록 버림
+		}
+	}
+}
+
+func (b *MemoryBroker) Subscribe(topic string) (<-chan Event, func()) {
+	ch := make(chan Event, b.buffer)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(ch)
+		return ch, func() {}
+	}
+	if b.subs[topic] == nil {
+		b.subs[topic] = map[chan Event]struct{}{}
+	}
+	b.subs[topic][ch] = struct{}{}
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() { b.unsubscribe(topic, ch) })
+	}
+}
+
+func (b *MemoryBroker) unsubscribe(topic string, ch chan Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subs[topic][ch]; !ok { //Close로 이미 닫힌 채널
+		return
+	}
+	delete(b.subs[topic], ch)
+	if len(b.subs[topic]) <= 0 {
+		delete(b.subs, topic)
+	}
+	close(ch)
+}
+
+func (b *MemoryBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for topic, chs := range b.subs {
+		for ch := range chs {
+			close(ch)
+		}
+		delete(b.subs, topic)
+	}
+	b.closed = true
+}
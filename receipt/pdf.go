@@ -0,0 +1,81 @@
+package receipt
+
+//pdf.go : 영수증 PDF 출력, 외부 라이브러리 없이 PDF 기본 한글 글꼴(Adobe-Korea1) 사용
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"unicode/utf16"
+)
+
+const (
+	pdfFont     = "HYGoThic-Medium" //PDF 뷰어 기본 한글 고딕, 글꼴을 포함하지 않아 파일이 작음
+	pdfFontSize = 9.0
+	pdfLeading  = 12.0 //줄 간격
+	pdfMargin   = 18.0
+)
+
+// 영수증 PDF, 감열 프린터 텍스트와 같은 줄을 글자 폭 격자(반각 1칸)에 맞춰 배치해 정렬 유지
+// 용지 폭은 width칸에 맞추고 길이는 줄 수만큼 늘어남
+func (r Receipt) PDF(width int) []byte {
+	if width <= 0 {
+		width = Width
+	}
+	lines := r.Lines(width)
+	cell := pdfFontSize / 2
+	pageW := pdfMargin*2 + cell*float64(width)
+	pageH := pdfMargin*2 + pdfLeading*float64(len(lines))
+
+	var content bytes.Buffer
+	fmt.Fprintf(&content, "BT\n/F1 %.1f Tf\n", pdfFontSize)
+	for i, line := range lines {
+		y := pageH - pdfMargin - pdfLeading*float64(i+1) + (pdfLeading-pdfFontSize)/2
+		col := 0
+		for _, ch := range line {
+			w := runeWidth(ch)
+			if ch != ' ' {
+				fmt.Fprintf(&content, "1 0 0 1 %.2f %.2f Tm <%s> Tj\n", pdfMargin+cell*float64(col), y, pdfHex(ch))
+			}
+			col += w
+		}
+	}
+	content.WriteString("ET\n")
+
+	var stream bytes.Buffer
+	zw := zlib.NewWriter(&stream)
+	zw.Write(content.Bytes())
+	zw.Close()
+
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 5 0 R >> >> /Contents 4 0 R >>", pageW, pageH),
+		fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", stream.Len(), stream.String()),
+		fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /UniKS-UCS2-H /DescendantFonts [6 0 R] >>", pdfFont),
+		fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType0 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Korea1) /Supplement 1 >> /FontDescriptor 7 0 R /DW 1000 >>", pdfFont),
+		fmt.Sprintf("<< /Type /FontDescriptor /FontName /%s /Flags 4 /FontBBox [-6 -145 1003 880] /ItalicAngle 0 /Ascent 880 /Descent -120 /CapHeight 880 /StemV 93 >>", pdfFont),
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return out.Bytes()
+}
+
+// UCS-2 글자 코드, 기본 다국어 평면 밖의 글자는 ?로 표시
+func pdfHex(ch rune) string {
+	if ch > 0xFFFF || utf16.IsSurrogate(ch) {
+		ch = '?'
+	}
+	return fmt.Sprintf("%04X", ch)
+}
@@ -0,0 +1,183 @@
+package receipt
+
+//receipt.go : 주문 영수증 항목 및 감열 프린터용 텍스트 출력
+import (
+	"fmt"
+	"strings"
+)
+
+const Width = 42 //80mm 감열 프린터 한 줄 글자 수 (한글은 2칸)
+
+// 영수증 메뉴 한 줄
+type Item struct {
+	Name    string   //메뉴 이름
+	Details []string //선택 옵션, 세트 구성
+	Amount  int      //금액
+}
+
+// 메뉴 금액 이후 더하거나 빼는 금액 ex. 배달비, 할인
+type Adjustment struct {
+	Label  string
+	Amount int
+}
+
+// 주문 영수증, 금액은 통화의 최소 단위 정수
+type Receipt struct {
+	StoreName    string
+	StoreAddress string
+	StorePhone   string
+	OrderID      string
+	OrderTime    string
+	Fulfilment   string
+	State        string
+	Items        []Item
+	Subtotal     int          //메뉴 금액
+	Adjustments  []Adjustment //배달비, 할인, 적립금 사용
+	Total        int          //결제 금액 (부가세 포함)
+	Currency     string       //통화
+	Digits       int          //통화 소수 자릿수
+	VATRate      int          //부가세율 (%)
+	Supply       int          //공급가액
+	VAT          int          //부가세
+	Payment      string       //결제 수단 및 상태
+	Notes        []string     //취소 및 환불 안내
+	IssuedAt     string       //발행 시간
+}
+
+// 금액 표시, 천 단위 구분 및 통화 소수 자릿수 ex. 12,300 / 12.30
+func FormatAmount(amount, digits int) string {
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	unit := 1
+	for i := 0; i < digits; i++ {
+		unit *= 10
+	}
+	whole := fmt.Sprint(amount / unit)
+	for i := len(whole) - 3; i > 0; i -= 3 {
+		whole = whole[:i] + "," + whole[i:]
+	}
+	if digits > 0 {
+		return fmt.Sprintf("%s%s.%0*d", sign, whole, digits, amount%unit)
+	}
+	return sign + whole
+}
+
+// 글자 표시 폭, 한글 및 전각 문자는 2칸
+func displayWidth(s string) int {
+	w := 0
+	for _, r := range s {
+		w += runeWidth(r)
+	}
+	return w
+}
+
+func runeWidth(r rune) int {
+	switch {
+	case r >= 0x1100 && r <= 0x115F, r >= 0x2E80 && r <= 0xA4CF, r >= 0xAC00 && r <= 0xD7A3,
+		r >= 0xF900 && r <= 0xFAFF, r >= 0xFE30 && r <= 0xFE4F, r >= 0xFF00 && r <= 0xFF60, r >= 0xFFE0 && r <= 0xFFE6:
+		return 2
+	}
+	return 1
+}
+
+// 왼쪽 글자와 오른쪽 정렬 금액 한 줄, 넘치면 금액을 다음 줄에 표시
+func row(left, right string, width int) []string {
+	space := width - displayWidth(left) - displayWidth(right)
+	if space >= 1 {
+		return []string{left + strings.Repeat(" ", space) + right}
+	}
+	lines := wrap(left, width)
+	if right != "" {
+		lines = append(lines, strings.Repeat(" ", max(width-displayWidth(right), 0))+right)
+	}
+	return lines
+}
+
+// 표시 폭 기준으로 줄 나누기
+func wrap(s string, width int) []string {
+	lines := []string{}
+	line, w := "", 0
+	for _, r := range s {
+		if rw := runeWidth(r); w+rw > width {
+			lines = append(lines, line)
+			line, w = string(r), rw
+		} else {
+			line, w = line+string(r), w+rw
+		}
+	}
+	return append(lines, line)
+}
+
+// 가운데 정렬
+func center(s string, width int) []string {
+	if w := displayWidth(s); w < width {
+		return []string{strings.Repeat(" ", (width-w)/2) + s}
+	}
+	return wrap(s, width)
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// 영수증 줄 단위 출력, width는 한 줄 표시 폭
+func (r Receipt) Lines(width int) []string {
+	if width <= 0 {
+		width = Width
+	}
+	amount := func(n int) string { return FormatAmount(n, r.Digits) }
+	rule := strings.Repeat("-", width)
+	lines := []string{}
+	add := func(l ...string) { lines = append(lines, l...) }
+
+	add(center("영수증", width)...)
+	add(center(r.StoreName, width)...)
+	if r.StoreAddress != "" {
+		add(wrap(r.StoreAddress, width)...)
+	}
+	if r.StorePhone != "" {
+		add("TEL " + r.StorePhone)
+	}
+	add(rule)
+	add(wrap("주문번호 "+r.OrderID, width)...)
+	add("주문일시 " + r.OrderTime)
+	add("수령방법 " + r.Fulfilment)
+	add("주문상태 " + r.State)
+	add(rule)
+
+	add(row("메뉴", "금액("+r.Currency+")", width)...)
+	for _, item := range r.Items {
+		add(row(item.Name, amount(item.Amount), width)...)
+		for _, d := range item.Details {
+			add(wrap("  "+d, width)...)
+		}
+	}
+	add(rule)
+	add(row("메뉴 금액", amount(r.Subtotal), width)...)
+	for _, a := range r.Adjustments {
+		add(row(a.Label, amount(a.Amount), width)...)
+	}
+	add(rule)
+	add(row("합계", amount(r.Total), width)...)
+	add(row(" 공급가액", amount(r.Supply), width)...)
+	add(row(fmt.Sprintf(" 부가세(%d%%)", r.VATRate), amount(r.VAT), width)...)
+	add(rule)
+	if r.Payment != "" {
+		add("결제 " + r.Payment)
+	}
+	for _, n := range r.Notes {
+		add(wrap(n, width)...)
+	}
+	add("발행 " + r.IssuedAt)
+	return lines
+}
+
+// 감열 프린터용 텍스트
+func (r Receipt) Text(width int) string {
+	return strings.Join(r.Lines(width), "\n") + "\n"
+}
@@ -0,0 +1,30 @@
+package receipt
+
+import "testing"
+
+func TestFormatAmount(t *testing.T) {
+	tests := []struct {
+		amount, digits int
+		want           string
+	}{
+		{0, 0, "0"},
+		{999, 0, "999"},
+		{1000, 0, "1,000"},
+		{100000, 0, "100,000"},
+		{1234567, 0, "1,234,567"},
+		{-12300, 0, "-12,300"},
+		{-999, 0, "-999"},
+		{0, 2, "0.00"},
+		{5, 2, "0.05"},
+		{1230, 2, "12.30"},
+		{123456789, 2, "1,234,567.89"},
+		{-5, 2, "-0.05"},
+		{-100000, 2, "-1,000.00"},
+		{1234, 3, "1.234"},
+	}
+	for _, tt := range tests {
+		if got := FormatAmount(tt.amount, tt.digits); got != tt.want {
+			t.Errorf("FormatAmount(%d, %d) = %q, want %q", tt.amount, tt.digits, got, tt.want)
+		}
+	}
+}
@@ -27,9 +27,9 @@ func CORS() gin.HandlerFunc {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 		//허용할 header 타입에 대해 열거
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, X-Forwarded-For, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, X-Forwarded-For, Authorization, accept, origin, Cache-Control, X-Requested-With, Idempotency-Key")
 		//허용할 method에 대해 열거
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
 			return
@@ -49,6 +49,7 @@ func liteAuth() gin.HandlerFunc {
 		auth := c.GetHeader("Authorization")
 		//실제 인증기능이 올수있다. 단순히 출력기능만 처리 현재는 출력예시
 		fmt.Println("Authorization-word ", auth)
+		c.Set("user", auth) //요청자 정보, 답글 작성자 등 기록에 사용
 
 		c.Next() // 다음 요청 진행
 	}
@@ -64,27 +65,101 @@ func (p *Router) Idx() *gin.Engine {
 
 	logger.Info("start server")
 	e.GET("/swagger/:any", ginSwg.WrapHandler(swgFiles.Handler))
-	docs.SwaggerInfo.Host = "localhost" //swagger 정보 등록
+	docs.SwaggerInfo.Host = "localhost"  //swagger 정보 등록
+	e.GET("/images/*key", p.ct.GetImage) //리뷰 이미지 제공, config의 storage.baseUrl과 동일
 
 	customer := e.Group("/customer", liteAuth())
 	{
 		fmt.Println(customer)
-		customer.GET("/getMenu/:sortOption", p.ct.GetMenu)   //메뉴 리스트 출력 조회
-		customer.GET("/getReview/:menuName", p.ct.GetReview) //메뉴별 평점 및 리뷰 조회
-		customer.POST("/writeReview", p.ct.WriteReview)      //메뉴별 평점 작성
-		customer.POST("orderMenu", p.ct.OrderMenu)           //메뉴 선택 후 주문
-		customer.PUT("changeMenu", p.ct.ChangeMenu)          // 메뉴변경
-		customer.PUT("addMenu", p.ct.AddMenu)                //메뉴 추가
-		customer.GET("getOrderState", p.ct.GetAllOrderList)  //주문 내역(상태) 조회
+		customer.GET("/getStoreList", p.ct.GetStoreList)                 //사업장 목록 조회
+		customer.GET("/getStore/:storeId", p.ct.GetStore)                //사업장 정보 조회
+		customer.GET("/getProfile", p.ct.GetProfile)                     //고객 정보 및 주소록 조회
+		customer.PUT("/updateProfile", p.ct.UpdateProfile)               //고객 정보 수정
+		customer.POST("/addAddress", p.ct.AddAddress)                    //배달 주소 저장
+		customer.PUT("/updateAddress", p.ct.UpdateAddress)               //배달 주소 수정
+		customer.DELETE("/deleteAddress/:addressId", p.ct.DeleteAddress) //배달 주소 삭제
+		customer.PUT("/setDefaultAddress", p.ct.SetDefaultAddress)       //기본 배달 주소 변경
+		customer.GET("/checkDeliveryZone", p.ct.CheckDeliveryZone)       //배달 가능 여부 및 배달비 조회
+		customer.GET("/getMenu/:sortOption", p.ct.GetMenu)               //메뉴 리스트 출력 조회
+		customer.GET("/getMenuDetail/:menuId", p.ct.GetMenuDetail)       //메뉴 상세 정보 조회
+		customer.GET("/getReview/:menuName", p.ct.GetReview)             //메뉴별 평점 및 리뷰 조회
+		customer.GET("/getReviewList/:menuName", p.ct.GetReviewList)     //메뉴별 전체 리뷰 및 통계 조회
+		customer.POST("/writeReview", p.ct.WriteReview)                  //메뉴별 평점 작성
+		customer.PUT("/updateReview", p.ct.UpdateReview)                 //본인 리뷰 수정
+		customer.DELETE("/deleteReview/:reviewId", p.ct.DeleteReview)    //본인 리뷰 삭제
+		customer.POST("/reportReview", p.ct.ReportReview)                //리뷰 신고
+		customer.POST("orderMenu", p.ct.Idempotent(), p.ct.OrderMenu)    //메뉴 선택 후 주문, Idempotency-Key 재시도 처리
+		customer.PUT("changeMenu", p.ct.ChangeMenu)                      // 메뉴변경
+		customer.PUT("addMenu", p.ct.AddMenu)                            //메뉴 추가
+		customer.PUT("/confirmPayment", p.ct.ConfirmPayment)             //카드 결제 승인
+		customer.PUT("/cancelOrder", p.ct.CancelOrder)                   //주문 취소
+		customer.GET("getOrderState", p.ct.GetAllOrderList)              //주문 내역(상태) 조회
+		customer.GET("/trackOrder/:orderId", p.ct.TrackOrder)            //배달원 위치 및 도착 예정 시간 조회
+		customer.GET("/subscribeOrder/:orderId", p.ct.SubscribeOrder)    //주문 상태 실시간 구독 (SSE)
+		customer.GET("/getLoyalty", p.ct.GetLoyalty)                     //적립금 및 스탬프 조회
+		customer.GET("/getLoyaltyLedger", p.ct.GetLoyaltyLedger)         //적립금 및 스탬프 기록 조회
+		customer.GET("/getReceipt/:orderId", p.ct.GetReceipt)            //영수증 조회 (json, text, pdf)
 	}
 
-	seller := e.Group("/seller", liteAuth())
+	seller := e.Group("/seller", liteAuth(), p.ct.SellerStore()) //계정 소속 사업장에서만 처리
 	{
 		fmt.Println(seller)
-		seller.PUT("/updateMenu", p.ct.UpdateMenu)             //메뉴 수정
-		seller.POST("/register", p.ct.RegisterMenu)            //신규메뉴 등록
-		seller.PUT("/updateOrderState", p.ct.UpdateOrderState) //주문내역 조회 및 상태 변경
-		seller.DELETE("/delete/:menu", p.ct.DeleteMenu)        //메뉴 삭제
+		seller.GET("/getStore", p.ct.GetStore)                     //본인 사업장 정보 조회
+		seller.PUT("/updateStore", p.ct.UpdateStore)               //사업장 정보 수정
+		seller.PUT("/pauseOrders", p.ct.PauseOrders)               //주문 일시 중지 및 재개
+		seller.PUT("/updateMenu", p.ct.UpdateMenu)                 //메뉴 수정
+		seller.PATCH("/updateMenu", p.ct.UpdateMenu)               //메뉴 부분 수정
+		seller.POST("/register", p.ct.RegisterMenu)                //신규메뉴 등록
+		seller.PUT("/updateOrderState", p.ct.UpdateOrderState)     //주문내역 조회 및 상태 변경
+		seller.DELETE("/delete/:menu", p.ct.DeleteMenu)            //메뉴 삭제
+		seller.GET("/getDeletedMenu", p.ct.GetDeletedMenu)         //삭제된 메뉴 조회
+		seller.PUT("/restore/:menu", p.ct.RestoreMenu)             //삭제된 메뉴 복구
+		seller.PUT("/soldOut/:menu", p.ct.SoldOut)                 //품절 설정 및 해제
+		seller.PUT("/setAvailability", p.ct.SetAvailability)       //한정 수량 및 주문 가능 시간 설정
+		seller.PUT("/setOptionGroups", p.ct.SetOptionGroups)       //메뉴 옵션 그룹 설정
+		seller.PUT("/setComboSlots", p.ct.SetComboSlots)           //세트 메뉴 구성 설정
+		seller.GET("/getOrderList", p.ct.GetAllOrderList)          //주문 내역 및 선택 옵션 조회
+		seller.GET("/getScheduledOrders", p.ct.GetScheduledOrders) //대기중인 예약 주문 조회
+		seller.GET("/kitchenStream", p.ct.KitchenStream)           //주방 화면 실시간 주문 (SSE)
+		seller.PUT("/ackOrder", p.ct.AckOrder)                     //주방 주문 확인
+		seller.PUT("/rejectOrder", p.ct.RejectOrder)               //주문 거절
+		seller.PUT("/bumpOrder", p.ct.BumpOrder)                   //주방 조리 단계 넘기기
+		seller.GET("/getAvailableRiders", p.ct.GetAvailableRiders) //배차 대기 배달원 조회
+		seller.PUT("/assignRider", p.ct.AssignRider)               //배달원 배차
+		seller.POST("/createPromotion", p.ct.CreatePromotion)      //쿠폰 등록
+		seller.PUT("/retirePromotion", p.ct.RetirePromotion)       //쿠폰 사용 중지
+		seller.GET("/getPromotions", p.ct.GetPromotions)           //쿠폰 목록 조회
+		seller.POST("/createStampCard", p.ct.CreateStampCard)      //스탬프 카드 등록
+		seller.PUT("/retireStampCard", p.ct.RetireStampCard)       //스탬프 카드 사용 중지
+		seller.GET("/getStampCards", p.ct.GetStampCards)           //스탬프 카드 목록 조회
+		seller.GET("/printReceipt/:orderId", p.ct.PrintReceipt)    //영수증 출력 (json, text, pdf)
+		seller.PUT("/replyReview", p.ct.ReplyReview)               //리뷰 답글 작성
+		seller.POST("/reportReview", p.ct.ReportReview)            //리뷰 신고
+	}
+
+	rider := e.Group("/rider", liteAuth(), p.ct.RiderAuth()) //등록된 배달원 계정만 처리
+	{
+		fmt.Println(rider)
+		rider.PUT("/setStatus", p.ct.SetRiderStatus)           //근무 시작 및 종료
+		rider.GET("/getOrder", p.ct.GetRiderOrder)             //배차된 주문 조회
+		rider.PUT("/accept", p.ct.AcceptDelivery)              //배차 수락
+		rider.PUT("/reject", p.ct.RejectDelivery)              //배차 거절
+		rider.PUT("/pickUp", p.ct.PickUpDelivery)              //메뉴 픽업
+		rider.PUT("/deliver", p.ct.CompleteDelivery)           //배달 완료
+		rider.PUT("/updateLocation", p.ct.UpdateRiderLocation) //현재 위치 전송
+	}
+
+	admin := e.Group("/admin", liteAuth(), p.ct.AdminAuth()) //설정된 관리자 계정만 처리
+	{
+		fmt.Println(admin)
+		admin.GET("/getFlaggedReviews", p.ct.GetFlaggedReviews) //검토 대상 리뷰 조회
+		admin.PUT("/hideReview", p.ct.HideReview)               //리뷰 숨김 처리
+		admin.DELETE("/purgeMenu/:menu", p.ct.PurgeMenu)        //삭제된 메뉴 영구 삭제
+		admin.POST("/createStore", p.ct.CreateStore)            //사업장 등록
+		admin.PUT("/addStaff", p.ct.AddStaff)                   //사업장 계정 추가
+		admin.DELETE("/removeStaff", p.ct.RemoveStaff)          //사업장 계정 삭제
+		admin.PUT("/assignLegacyData", p.ct.AssignLegacyData)   //기존 데이터 사업장 지정
+		admin.POST("/createRider", p.ct.CreateRider)            //배달원 등록
 	}
 
 	return e
@@ -0,0 +1,114 @@
+package router
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"lecture/oos/conf"
+	ctl "lecture/oos/controller"
+	"lecture/oos/logger"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DB 없이 라우팅과 요청 검증만 확인하는 router
+func newTestRouter(t *testing.T, cf *conf.Config) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	cf.Log = conf.Log{Level: "error", Fpath: filepath.Join(t.TempDir(), "oos"), Msize: 1, Mage: 1, Mbackup: 1}
+	if err := logger.InitLogger(cf); err != nil {
+		t.Fatal(err)
+	}
+	controller, err := ctl.NewCTL(nil, cf, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewRouter(controller)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r.Idx()
+}
+
+func TestGetMenuDetailRoute(t *testing.T) {
+	e := newTestRouter(t, &conf.Config{})
+
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/customer/getMenuDetail/not-an-id", nil))
+	if w.Code != http.StatusUnprocessableEntity { //등록되지 않았으면 404
+		t.Errorf("GET /customer/getMenuDetail/:menuId = %d, want %d", w.Code, http.StatusUnprocessableEntity)
+	}
+}
+
+func TestAdminAuth(t *testing.T) {
+	cf := &conf.Config{}
+	cf.Admin.Accounts = []string{"admin-token"}
+	e := newTestRouter(t, cf)
+
+	tests := []struct {
+		name, method, path, auth string
+		want                     int
+	}{
+		{"no account", http.MethodPut, "/admin/addStaff", "", http.StatusForbidden},
+		{"customer account", http.MethodPut, "/admin/addStaff", "01012345678", http.StatusForbidden},
+		{"create store", http.MethodPost, "/admin/createStore", "someone", http.StatusForbidden},
+		{"assign legacy data", http.MethodPut, "/admin/assignLegacyData", "someone", http.StatusForbidden},
+		{"hide review", http.MethodPut, "/admin/hideReview", "someone", http.StatusForbidden},
+		{"create rider", http.MethodPost, "/admin/createRider", "someone", http.StatusForbidden},
+		{"admin account", http.MethodPut, "/admin/hideReview", "admin-token", http.StatusUnprocessableEntity}, //reviewId 없음
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", tt.auth)
+			w := httptest.NewRecorder()
+			e.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("%s %s = %d, want %d", tt.method, tt.path, w.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestIdempotencyKeyNeedsCaller(t *testing.T) {
+	e := newTestRouter(t, &conf.Config{})
+
+	req := httptest.NewRequest(http.MethodPost, "/customer/orderMenu", strings.NewReader("menu=bulgogi&storeId=000000000000000000000000"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Idempotency-Key", "retry-1")
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, req)
+	if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), "Idempotency-Key needs Authorization or pnum") {
+		t.Errorf("anonymous Idempotency-Key = %d %s, want 422", w.Code, w.Body.String())
+	}
+}
+
+func TestCouponNeedsPnum(t *testing.T) {
+	e := newTestRouter(t, &conf.Config{})
+
+	req := httptest.NewRequest(http.MethodPost, "/customer/orderMenu", strings.NewReader("menu=bulgogi&storeId=000000000000000000000000&coupon=WELCOME"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	e.ServeHTTP(w, req)
+	if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), "pnum is required to use a coupon") {
+		t.Errorf("coupon without pnum = %d %s, want 422", w.Code, w.Body.String())
+	}
+}
+
+func TestLoyaltyNeedsPnum(t *testing.T) {
+	e := newTestRouter(t, &conf.Config{})
+
+	for _, form := range []string{"usePoints=500", "stampCard=000000000000000000000000"} {
+		t.Run(form, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/customer/orderMenu", strings.NewReader("menu=bulgogi&storeId=000000000000000000000000&"+form))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			w := httptest.NewRecorder()
+			e.ServeHTTP(w, req)
+			if w.Code != http.StatusUnprocessableEntity || !strings.Contains(w.Body.String(), "pnum is required to use points or a stamp card") {
+				t.Errorf("%s without pnum = %d %s, want 422", form, w.Code, w.Body.String())
+			}
+		})
+	}
+}
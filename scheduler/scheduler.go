@@ -0,0 +1,73 @@
+package scheduler
+
+//scheduler.go : 예약 주문을 주방 전달 시간에 접수중으로 변경하고 결제 대기 시간이 지난 주문을 취소하는 백그라운드 작업
+import (
+	"context"
+	"lecture/oos/conf"
+	"lecture/oos/logger"
+	"lecture/oos/model"
+	"lecture/oos/pubsub"
+	"time"
+)
+
+// 결제 대기 시간이 지난 주문 취소, 수량, 쿠폰, 적립금 복구와 결제 요청 취소는 controller에서 처리
+type PaymentExpirer interface {
+	ExpirePendingPayments(now time.Time) int
+}
+
+type Scheduler struct {
+	md       *model.Model
+	ps       pubsub.Broker
+	pe       PaymentExpirer
+	interval time.Duration
+}
+
+func NewScheduler(rep *model.Model, cf *conf.Config, ps pubsub.Broker, pe PaymentExpirer) (*Scheduler, error) {
+	interval := time.Duration(cf.Schedule.Interval) * time.Second
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	r := &Scheduler{md: rep, ps: ps, pe: pe, interval: interval}
+	return r, nil
+}
+
+// ctx가 종료될 때까지 주기적으로 예약 주문 전달 및 결제 대기 주문 취소
+func (p *Scheduler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	p.release() //서버 중지 중 지난 예약 주문부터 전달
+	p.expire()
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("scheduler stop")
+			return nil
+		case <-ticker.C:
+			p.release()
+			p.expire()
+		}
+	}
+}
+
+func (p *Scheduler) release() {
+	orders, err := p.md.ReleaseScheduledOrders(time.Now())
+	if err != nil {
+		logger.Error("failed to release scheduled orders", err)
+		return
+	}
+	for _, order := range orders { //주문 구독자에게 접수 알림, 주방 화면에는 새 주문으로 전달
+		p.ps.Publish(pubsub.OrderTopic(order.ID.Hex()), pubsub.Event{Type: pubsub.EventState, OrderID: order.ID.Hex(), State: order.State})
+		p.ps.Publish(pubsub.StoreTopic(order.StoreID.Hex()), pubsub.Event{Type: pubsub.EventNew, OrderID: order.ID.Hex(), State: order.State, Data: order,
+			Alert: &pubsub.Alert{Sound: pubsub.SoundNewOrder, Repeat: true}})
+	}
+	if len(orders) > 0 {
+		logger.Info("released scheduled orders ", len(orders))
+	}
+}
+
+func (p *Scheduler) expire() {
+	if n := p.pe.ExpirePendingPayments(time.Now()); n > 0 {
+		logger.Info("cancelled expired pending payments ", n)
+	}
+}
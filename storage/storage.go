@@ -0,0 +1,86 @@
+package storage
+
+//storage.go : 리뷰 이미지 등 파일(blob) 저장소 인터페이스 및 로컬 파일시스템 구현
+import (
+	"errors"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// 파일 저장소 인터페이스, 추후 S3 호환 저장소로 교체 가능
+type BlobStore interface {
+	Put(key string, r io.Reader) error
+	Get(key string) (io.ReadCloser, string, error) //내용, content-type 반환
+	Delete(key string) error
+}
+
+// 로컬 파일시스템 저장소
+type LocalStore struct {
+	root string
+}
+
+func NewLocalStore(root string) (*LocalStore, error) {
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, err
+	}
+	return &LocalStore{root: root}, nil
+}
+
+// key를 저장소 내부 경로로 변환, 저장소 밖을 가리키는 key는 거부
+func (s *LocalStore) fpath(key string) (string, error) {
+	clean := path.Clean("/" + key)
+	if clean == "/" || strings.Contains(key, "..") {
+		return "", ErrInvalidKey
+	}
+	return filepath.Join(s.root, filepath.FromSlash(clean)), nil
+}
+
+func (s *LocalStore) Put(key string, r io.Reader) error {
+	fp, err := s.fpath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fp), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(fp)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err := io.Copy(file, r); err != nil {
+		os.Remove(fp)
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStore) Get(key string) (io.ReadCloser, string, error) {
+	fp, err := s.fpath(key)
+	if err != nil {
+		return nil, "", err
+	}
+	file, err := os.Open(fp)
+	if err != nil {
+		return nil, "", err
+	}
+	return file, mime.TypeByExtension(path.Ext(key)), nil
+}
+
+func (s *LocalStore) Delete(key string) error {
+	fp, err := s.fpath(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(fp); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}